@@ -5,6 +5,7 @@ import (
 	"github.com/k1LoW/gostyle/analyzer/code_review_comments/dontpanic"
 	"github.com/k1LoW/gostyle/analyzer/code_review_comments/errorstrings"
 	"github.com/k1LoW/gostyle/analyzer/code_review_comments/handlerrors"
+	"github.com/k1LoW/gostyle/analyzer/code_review_comments/initialisms"
 	"github.com/k1LoW/gostyle/analyzer/decisions/funcfmt"
 	"github.com/k1LoW/gostyle/analyzer/decisions/getters"
 	"github.com/k1LoW/gostyle/analyzer/decisions/nilslices"
@@ -18,6 +19,7 @@ import (
 	"github.com/k1LoW/gostyle/analyzer/decisions/varnames"
 	"github.com/k1LoW/gostyle/analyzer/effective/ifacenames"
 	"github.com/k1LoW/gostyle/analyzer/guide/mixedcaps"
+	"github.com/k1LoW/gostyle/analyzer/nostyle"
 	"github.com/k1LoW/gostyle/config"
 	"golang.org/x/tools/go/analysis"
 )
@@ -31,9 +33,11 @@ var Analyzers = []*analysis.Analyzer{
 	getters.AnalyzerWithConfig,
 	handlerrors.AnalyzerWithConfig,
 	ifacenames.AnalyzerWithConfig,
+	initialisms.AnalyzerWithConfig,
 	pkgnames.AnalyzerWithConfig,
 	mixedcaps.AnalyzerWithConfig,
 	nilslices.AnalyzerWithConfig,
+	nostyle.AnalyzerWithConfig,
 	recvnames.AnalyzerWithConfig,
 	recvtype.AnalyzerWithConfig,
 	repetition.AnalyzerWithConfig,
@@ -42,3 +46,27 @@ var Analyzers = []*analysis.Analyzer{
 	useq.AnalyzerWithConfig,
 	varnames.AnalyzerWithConfig,
 }
+
+// AnalyzersWithFlags is the list of analyzers configured by flags instead of the config file.
+// Their flags are the source of the descriptions and the defaults of the settings in the config file.
+var AnalyzersWithFlags = []*analysis.Analyzer{
+	contexts.Analyzer,
+	dontpanic.Analyzer,
+	errorstrings.Analyzer,
+	funcfmt.Analyzer,
+	getters.Analyzer,
+	handlerrors.Analyzer,
+	ifacenames.Analyzer,
+	initialisms.Analyzer,
+	pkgnames.Analyzer,
+	mixedcaps.Analyzer,
+	nilslices.Analyzer,
+	nostyle.Analyzer,
+	recvnames.Analyzer,
+	recvtype.Analyzer,
+	repetition.Analyzer,
+	underscores.Analyzer,
+	useany.Analyzer,
+	useq.Analyzer,
+	varnames.Analyzer,
+}
@@ -3,10 +3,10 @@ package contexts
 import (
 	"fmt"
 	"go/ast"
-	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -15,11 +15,18 @@ import (
 
 const (
 	name = "contexts"
-	doc  = "Analyzer based on https://go.dev/wiki/CodeReviewComments#contexts"
+	url  = "https://go.dev/wiki/CodeReviewComments#contexts"
+	doc  = "Analyzer based on " + url
 	msgp = "Most functions that use a Context should accept it as their first parameter. (ref: https://go.dev/wiki/CodeReviewComments#contexts )"
 	msgs = "Don't add a Context member to a struct type; instead add a ctx parameter to each method on that type that needs to pass it along. The one exception is for methods whose signature must match an interface in the standard library or in a third party library. (ref: https://go.dev/wiki/CodeReviewComments#contexts )"
 )
 
+// Message kinds.
+const (
+	kindParameter = "parameter"
+	kindStruct    = "struct"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
@@ -50,23 +57,17 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Contexts.IncludeGenerated
-		excludeTest = c.AnalyzersSettings.Contexts.ExcludeTest
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
+	flags := config.Contexts{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Contexts]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -75,22 +76,14 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.StructType)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch nn := n.(type) {
 		case *ast.FuncDecl:
-			if excludeTest {
-				if strings.HasSuffix(pass.Fset.File(nn.Pos()).Name(), "_test.go") {
-					return
-				}
-			}
 			if len(nn.Type.Params.List) < 2 {
 				return
 			}
@@ -104,15 +97,10 @@ func run(pass *analysis.Pass) (any, error) {
 					continue
 				}
 				if id.Name == "context" && e.Sel.Name == "Context" {
-					r.Append(nn.Pos(), fmt.Sprintf("%s: %s", msgp, nn.Name.Name))
+					r.Append(nn.Pos(), fmt.Sprintf("%s: %s", msgp, nn.Name.Name), reporter.Kind(kindParameter))
 				}
 			}
 		case *ast.FuncLit:
-			if excludeTest {
-				if strings.HasSuffix(pass.Fset.File(nn.Pos()).Name(), "_test.go") {
-					return
-				}
-			}
 			if len(nn.Type.Params.List) < 2 {
 				return
 			}
@@ -126,15 +114,10 @@ func run(pass *analysis.Pass) (any, error) {
 					continue
 				}
 				if id.Name == "context" && e.Sel.Name == "Context" {
-					r.Append(nn.Pos(), msgp)
+					r.Append(nn.Pos(), msgp, reporter.Kind(kindParameter))
 				}
 			}
 		case *ast.StructType:
-			if excludeTest {
-				if strings.HasSuffix(pass.Fset.File(nn.Pos()).Name(), "_test.go") {
-					return
-				}
-			}
 			if len(nn.Fields.List) == 0 {
 				return
 			}
@@ -148,16 +131,17 @@ func run(pass *analysis.Pass) (any, error) {
 					continue
 				}
 				if id.Name == "context" && e.Sel.Name == "Context" {
-					r.Append(e.Pos(), fmt.Sprintf("%s: %s", msgs, f.Names[0].Name))
+					r.Append(e.Pos(), fmt.Sprintf("%s: %s", msgs, f.Names[0].Name), reporter.Kind(kindStruct))
 				}
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceCodeReviewComments, URL: url, Kinds: []string{kindParameter, kindStruct}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
 	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
@@ -3,10 +3,10 @@ package dontpanic
 import (
 	"fmt"
 	"go/ast"
-	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -15,7 +15,8 @@ import (
 
 const (
 	name = "dontpanic"
-	doc  = "Analyzer based on https://go.dev/wiki/CodeReviewComments#dont-panic"
+	url  = "https://go.dev/wiki/CodeReviewComments#dont-panic"
+	doc  = "Analyzer based on " + url
 	msg  = "Don't use panic for normal error handling. Use error and multiple return values. (ref: https://go.dev/wiki/CodeReviewComments#dont-panic )"
 )
 
@@ -49,36 +50,26 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
+	flags := config.Dontpanic{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
 
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Dontpanic.IncludeGenerated
-		excludeTest = c.AnalyzersSettings.Dontpanic.ExcludeTest
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
-	}
+func analyze(pass *analysis.Pass, s *config.Settings[config.Dontpanic]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.CallExpr)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch e := n.(type) {
@@ -90,19 +81,15 @@ func run(pass *analysis.Pass) (any, error) {
 			if id.Name != "panic" {
 				return
 			}
-			if excludeTest {
-				if strings.HasSuffix(pass.Fset.File(e.Pos()).Name(), "_test.go") {
-					return
-				}
-			}
 			r.Append(e.Pos(), msg)
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceCodeReviewComments, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
 	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
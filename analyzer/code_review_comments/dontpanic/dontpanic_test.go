@@ -1,9 +1,13 @@
 package dontpanic
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gostaticanalysis/testutil"
+	"github.com/k1LoW/gostyle/gitdiff"
+	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -13,3 +17,24 @@ func TestAnalyzer(t *testing.T) {
 	td := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, td, Analyzer, "a")
 }
+
+func TestAnalyzerWithChanges(t *testing.T) {
+	// The testdata is not copied by testutil.WithModules, since it prepends line directives and shifts the lines of the patch.
+	td := analysistest.TestData()
+	root := filepath.Join(td, "src", "changes")
+	f, err := os.Open(filepath.Join(root, "changes.patch"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	c, err := gitdiff.Parse(f, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The report outside the changed lines of a.go is dropped, and the report in the new file b.go is kept.
+	reporter.SetChanges(c)
+	t.Cleanup(func() {
+		reporter.SetChanges(nil)
+	})
+	analysistest.Run(t, td, Analyzer, "changes")
+}
@@ -0,0 +1,11 @@
+package changes
+
+import "errors"
+
+func f() {
+	panic(errors.New("unchanged"))
+}
+
+func g() {
+	panic(errors.New("changed")) // want "gostyle.dontpanic"
+}
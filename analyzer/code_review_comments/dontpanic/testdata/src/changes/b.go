@@ -0,0 +1,7 @@
+package changes
+
+import "errors"
+
+func h() {
+	panic(errors.New("new")) // want "gostyle.dontpanic"
+}
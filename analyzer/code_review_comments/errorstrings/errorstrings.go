@@ -3,27 +3,45 @@ package errorstrings
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 const (
 	name = "errorstrings"
-	doc  = "Analyzer based on https://go.dev/wiki/CodeReviewComments#error-strings"
+	url  = "https://go.dev/wiki/CodeReviewComments#error-strings"
+	doc  = "Analyzer based on " + url
 	msg  = "Error strings should not be capitalized (unless beginning with proper nouns or acronyms) or end with punctuation, since they are usually printed following other context. (ref: https://go.dev/wiki/CodeReviewComments#error-strings )"
 )
 
+// errorFuncs is the standard functions creating errors with the index of the error string argument.
+var errorFuncs = map[string]int{
+	"fmt.Errorf": 0,
+	"errors.New": 0,
+}
+
+// trailing is the punctuation and the newlines reported at the end of error strings and removed by the suggested fixes.
+const trailing = ".!:\r\n"
+
 var (
 	disable          bool
 	includeGenerated bool
 	excludeTest      bool
+	ctors            string
 )
 
 // Analyzer based on https://go.dev/wiki/CodeReviewComments#error-strings
@@ -50,87 +68,179 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
+	flags := config.Errorstrings{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Constructors:     strings.Split(ctors, ","),
 	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Errorstrings.IncludeGenerated
-		excludeTest = c.AnalyzersSettings.Errorstrings.ExcludeTest
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Errorstrings]) error {
+	ins := s.Initialisms
+	fs, err := detector.NewFuncs(s.Analyzer.Constructors)
+	if err != nil {
+		return err
 	}
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.CallExpr)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	i.Preorder(nodeFilter, func(n ast.Node) {
-		if excludeTest {
-			if strings.HasSuffix(pass.Fset.File(n.Pos()).Name(), "_test.go") {
-				return
-			}
+	check := func(e *ast.CallExpr, arg int) {
+		if arg >= len(e.Args) {
+			return
+		}
+		bl, ok := e.Args[arg].(*ast.BasicLit)
+		if !ok || bl.Kind != token.STRING {
+			return
+		}
+		v, err := strconv.Unquote(bl.Value)
+		if err != nil || !isNG(v) {
+			return
 		}
+		// The error strings beginning with proper nouns or acronyms are reported but not fixed.
+		var sfs []analysis.SuggestedFix
+		if to := fixed(pass, ins, bl.Pos(), v); to != v {
+			sfs = fixes(bl, to)
+		}
+		r.AppendWithFixes(e.Pos(), fmt.Sprintf("%s: %s", msg, bl.Value), sfs...)
+	}
+	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch e := n.(type) {
 		case *ast.CallExpr:
-			if len(e.Args) == 0 {
-				return
-			}
-			fn, ok := e.Fun.(*ast.SelectorExpr)
+			arg, ok := errorStringArg(pass, fs, e)
 			if !ok {
 				return
 			}
-			if fn.Sel.Name == "Errorf" {
-				bl, ok := e.Args[0].(*ast.BasicLit)
-				if !ok {
-					return
-				}
-				if isNG(bl.Value) {
-					r.Append(e.Pos(), fmt.Sprintf("%s: %s", msg, bl.Value))
-				}
-				return
-			}
-			id, ok := fn.X.(*ast.Ident)
-			if !ok {
-				return
-			}
-			if id.Name == "errors" && fn.Sel.Name == "New" {
-				bl, ok := e.Args[0].(*ast.BasicLit)
-				if !ok {
-					return
-				}
-				if isNG(bl.Value) {
-					r.Append(e.Pos(), fmt.Sprintf("%s: %s", msg, bl.Value))
-				}
-			}
+			check(e, arg)
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
+}
+
+// errorStringArg returns the index of the error string argument if the call is of fmt.Errorf, errors.New or the constructors.
+// The callee is resolved with the type information, so the aliased imports are also checked and the methods (e.g. t.Errorf) are not.
+func errorStringArg(pass *analysis.Pass, fs *detector.Funcs, e *ast.CallExpr) (int, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, e).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return 0, false
+	}
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		return 0, false
+	}
+	if arg, ok := errorFuncs[fn.Pkg().Path()+"."+fn.Name()]; ok {
+		return arg, true
+	}
+	arg, ok := fs.Arg(fn.Pkg().Path(), fn.Name())
+	if !ok {
+		return 0, false
+	}
+	if arg == detector.ArgOmitted {
+		return firstStringParam(fn.Signature())
+	}
+	return arg, true
+}
+
+// firstStringParam returns the index of the first string parameter of the function.
+func firstStringParam(sig *types.Signature) (int, bool) {
+	for i := range sig.Params().Len() {
+		if types.Identical(sig.Params().At(i).Type(), types.Typ[types.String]) {
+			return i, true
+		}
+	}
+	return 0, false
 }
 
-func isNG(in string) bool {
-	f := strings.Trim(in, "\"'`")
-	return unicode.IsUpper(rune(f[0])) || strings.HasSuffix(f, ".")
+// isNG reports whether the error string is capitalized or ends with punctuation or a newline.
+func isNG(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r) || (s != "" && strings.ContainsAny(s[len(s)-1:], trailing))
+}
+
+// fixed returns the error string with the first letter lowercased and the trailing punctuation and newlines removed.
+// The first letter is kept if the first word is an initialism (e.g. URL), an acronym (e.g. AWS), the pronoun I or an exported identifier in scope.
+func fixed(pass *analysis.Pass, ins *detector.Initialisms, pos token.Pos, s string) string {
+	s = strings.TrimRight(s, trailing)
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	if !unicode.IsUpper(r[0]) {
+		return s
+	}
+	w := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})[0]
+	if k, ok := ins.Lookup(w); ok && (w == k || w == strings.ToUpper(k)) {
+		return s
+	}
+	if w == "I" || (len([]rune(w)) > 1 && strings.ToUpper(w) == w) {
+		return s
+	}
+	if sc := pass.Pkg.Scope().Innermost(pos); sc != nil {
+		if _, o := sc.LookupParent(w, pos); o != nil && o.Exported() {
+			return s
+		}
+	}
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// fixes returns suggested fixes that replace the string literal with the error string.
+// The escape sequences of the literal are kept unless the literal cannot be rewritten in place.
+func fixes(bl *ast.BasicLit, to string) []analysis.SuggestedFix {
+	if to == "" {
+		return nil
+	}
+	q := bl.Value[:1]
+	body := bl.Value[1 : len(bl.Value)-1]
+	for {
+		if q == `"` && (strings.HasSuffix(body, `\n`) || strings.HasSuffix(body, `\r`)) {
+			body = body[:len(body)-2]
+			continue
+		}
+		if body != "" && strings.ContainsAny(body[len(body)-1:], trailing) {
+			body = body[:len(body)-1]
+			continue
+		}
+		break
+	}
+	if f := string([]rune(to)[:1]); !strings.HasPrefix(body, f) {
+		_, n := utf8.DecodeRuneInString(body)
+		body = f + body[n:]
+	}
+	lit := q + body + q
+	if v, err := strconv.Unquote(lit); err != nil || v != to {
+		lit = strconv.Quote(to)
+	}
+	return []analysis.SuggestedFix{
+		{
+			Message: fmt.Sprintf("Replace %s with %s", bl.Value, lit),
+			TextEdits: []analysis.TextEdit{
+				{
+					Pos:     bl.Pos(),
+					End:     bl.End(),
+					NewText: []byte(lit),
+				},
+			},
+		},
+	}
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceCodeReviewComments, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
 	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&ctors, "constructors", "", "functions creating errors (comma separated) in addition to fmt.Errorf and errors.New, with the index of the error string argument (e.g. github.com/pkg/errors.Wrapf:1). the first string argument is checked if the index is omitted")
 }
@@ -13,3 +13,33 @@ func TestAnalyzer(t *testing.T) {
 	td := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, td, Analyzer, "a")
 }
+
+// TestAnalyzerWithSuggestedFixes is a test for suggested fixes of Analyzer.
+func TestAnalyzerWithSuggestedFixes(t *testing.T) {
+	excludeTest = true
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestAnalyzerConstructors(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctors string
+	}{
+		{"with index", "xerrors.Errorf,b/xerrors.Wrapf:1"},
+		{"without index", "xerrors.Errorf,b/xerrors.Wrapf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Analyzer.Flags.Set("constructors", tt.ctors); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				if err := Analyzer.Flags.Set("constructors", ""); err != nil {
+					t.Fatal(err)
+				}
+			})
+			td := testutil.WithModules(t, analysistest.TestData(), nil)
+			analysistest.Run(t, td, Analyzer, "b/...")
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 )
 
+type Config struct{}
+
 func f() {
 	e := fmt.Errorf("This is %s", "world") // want "gostyle.errorstrings"
 	print(e.Error())
@@ -14,4 +16,28 @@ func f() {
 	print(e3.Error())
 	var e4 = errors.New("this is world.") // want "gostyle.errorstrings"
 	print(e4.Error())
+	e5 := errors.New("Something went wrong!\n") // want "gostyle.errorstrings"
+	print(e5.Error())
+	e6 := fmt.Errorf(`Invalid value: %q:`, "x") // want "gostyle.errorstrings"
+	print(e6.Error())
+	e7 := fmt.Errorf("Tab\tseparated\\n") // want "gostyle.errorstrings"
+	print(e7.Error())
+	e8 := fmt.Errorf("failed to read: %w", e)
+	print(e8.Error())
+	e9 := errors.New("URL is invalid") // want "gostyle.errorstrings"
+	print(e9.Error())
+	e10 := errors.New("AWS is unavailable") // want "gostyle.errorstrings"
+	print(e10.Error())
+	e11 := errors.New("Config is missing") // want "gostyle.errorstrings"
+	print(e11.Error())
+	e12 := errors.New("URL is invalid...") // want "gostyle.errorstrings"
+	print(e12.Error())
+	e13 := errors.New("")
+	print(e13.Error())
+	e14 := errors.New("I cannot open the file") // want "gostyle.errorstrings"
+	print(e14.Error())
+	e15 := errors.New("failed!") // want "gostyle.errorstrings"
+	print(e15.Error())
+	e16 := fmt.Errorf("failed: %w:\n", e) // want "gostyle.errorstrings"
+	print(e16.Error())
 }
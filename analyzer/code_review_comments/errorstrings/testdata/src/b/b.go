@@ -0,0 +1,28 @@
+package b
+
+import (
+	"b/xerrors"
+	stderrors "errors"
+	format "fmt"
+	"log"
+)
+
+type logger struct{}
+
+func (l *logger) Errorf(format string, a ...any) {}
+
+func f() {
+	e := format.Errorf("This is %s", "world") // want "gostyle.errorstrings"
+	print(e.Error())
+	e2 := stderrors.New("This is world") // want "gostyle.errorstrings"
+	print(e2.Error())
+	e3 := xerrors.Errorf("This is %s", "world") // want "gostyle.errorstrings"
+	print(e3.Error())
+	e4 := xerrors.Wrapf(e, "This is %s", "world") // want "gostyle.errorstrings"
+	print(e4.Error())
+	e5 := xerrors.New("This is world")
+	print(e5.Error())
+	l := &logger{}
+	l.Errorf("This is %s.", "world")
+	log.Printf("This is %s.", "world")
+}
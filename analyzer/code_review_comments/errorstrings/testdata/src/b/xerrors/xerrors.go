@@ -0,0 +1,15 @@
+package xerrors
+
+import "fmt"
+
+func Errorf(format string, a ...any) error {
+	return fmt.Errorf(format, a...)
+}
+
+func Wrapf(err error, format string, a ...any) error {
+	return fmt.Errorf(format+": %w", append(a, err)...)
+}
+
+func New(msg string) error {
+	return fmt.Errorf("%s", msg)
+}
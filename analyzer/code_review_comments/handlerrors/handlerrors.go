@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
-	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -16,7 +16,8 @@ import (
 
 const (
 	name = "handlerrors"
-	doc  = "Analyzer based on https://go.dev/wiki/CodeReviewComments#handle-errors"
+	url  = "https://go.dev/wiki/CodeReviewComments#handle-errors"
+	doc  = "Analyzer based on " + url
 	msg  = "Do not discard errors using `_` variables. If a function returns an error, check it to make sure the function succeeded. Handle the error, return it, or, in truly exceptional situations, panic. (ref: https://go.dev/wiki/CodeReviewComments#handle-errors )"
 )
 
@@ -69,35 +70,26 @@ var errTyp = func() *types.Interface {
 }()
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Handlerrors.IncludeGenerated
-		excludeTest = c.AnalyzersSettings.Handlerrors.ExcludeTest
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
+	flags := config.Handlerrors{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Handlerrors]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.AssignStmt)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	br := &blankErrReporter{
@@ -106,11 +98,6 @@ func run(pass *analysis.Pass) (any, error) {
 	}
 
 	i.Preorder(nodeFilter, func(n ast.Node) {
-		if excludeTest {
-			if strings.HasSuffix(pass.Fset.File(n.Pos()).Name(), "_test.go") {
-				return
-			}
-		}
 		switch nn := n.(type) {
 		case *ast.AssignStmt:
 			if len(nn.Rhs) == 0 {
@@ -133,10 +120,11 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceCodeReviewComments, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
 	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
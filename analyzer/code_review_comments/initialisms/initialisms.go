@@ -0,0 +1,198 @@
+package initialisms
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/gostaticanalysis/comment/passes/commentmap"
+	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/fixer"
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	name = "initialisms"
+	url  = "https://go.dev/wiki/CodeReviewComments#initialisms"
+	doc  = "Analyzer based on " + url
+	msg  = "Words in names that are initialisms or acronyms (e.g. \"URL\" or \"NATO\") have a consistent case. For example, \"URL\" should appear as \"URL\" or \"url\" (as in \"urlPony\", or \"URLPony\"), never as \"Url\". (ref: https://go.dev/wiki/CodeReviewComments#initialisms )"
+)
+
+var (
+	disable          bool
+	includeGenerated bool
+	excludeTest      bool
+	exclude          string
+)
+
+// Analyzer based on https://go.dev/wiki/CodeReviewComments#initialisms
+var Analyzer = &analysis.Analyzer{
+	Name: name,
+	Doc:  doc,
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		commentmap.Analyzer,
+	},
+}
+
+// AnalyzerWithConfig based on https://go.dev/wiki/CodeReviewComments#initialisms
+var AnalyzerWithConfig = &analysis.Analyzer{
+	Name: name,
+	Doc:  doc,
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		config.Loader,
+		inspect.Analyzer,
+		commentmap.Analyzer,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	flags := config.Initialisms{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Exclude:          detector.SplitExcludes(exclude),
+	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Initialisms]) error {
+	ins := s.Initialisms
+	ex, err := detector.NewExcludes(s.Analyzer.Exclude)
+	if err != nil {
+		return err
+	}
+	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.ImportSpec)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.TypeSpec)(nil),
+		(*ast.InterfaceType)(nil),
+		(*ast.StructType)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.RangeStmt)(nil),
+	}
+
+	r, err := reporter.New(name, pass, s.Options...)
+	if err != nil {
+		return err
+	}
+	check := func(id *ast.Ident) {
+		if id == nil || ex.Match(pass.Pkg.Path(), id.Name) {
+			return
+		}
+		to, ok := consistent(ins, id.Name)
+		if ok {
+			return
+		}
+		r.AppendWithFixes(id.Pos(), fmt.Sprintf("%s: %s (use %s)", msg, id.Name, to), fixer.Rename(pass, id, to)...)
+	}
+	i.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ImportSpec:
+			check(n.Name)
+		case *ast.ValueSpec:
+			for _, id := range n.Names {
+				check(id)
+			}
+		case *ast.TypeSpec:
+			check(n.Name)
+		case *ast.InterfaceType:
+			checkFields(n.Methods, check)
+		case *ast.StructType:
+			checkFields(n.Fields, check)
+		case *ast.FuncDecl:
+			check(n.Name)
+			checkFields(n.Recv, check)
+			checkFields(n.Type.Params, check)
+			checkFields(n.Type.Results, check)
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return
+			}
+			for _, e := range n.Lhs {
+				if id, ok := e.(*ast.Ident); ok {
+					check(id)
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok != token.DEFINE {
+				return
+			}
+			if id, ok := n.Key.(*ast.Ident); ok {
+				check(id)
+			}
+			if id, ok := n.Value.(*ast.Ident); ok {
+				check(id)
+			}
+		}
+	})
+	r.Report()
+	return nil
+}
+
+func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceCodeReviewComments, URL: url})
+	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
+	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&exclude, "exclude", "", detector.ExcludeUsage)
+}
+
+func checkFields(fl *ast.FieldList, check func(id *ast.Ident)) {
+	if fl == nil {
+		return
+	}
+	for _, f := range fl.List {
+		for _, id := range f.Names {
+			check(id)
+		}
+	}
+}
+
+// consistent reports whether the initialisms in the name have a consistent case.
+// If not, it returns the name with the initialisms recased according to the initialisms table (e.g. userId -> userID).
+// Names containing underscores are left to the mixedcaps analyzer.
+func consistent(ins *detector.Initialisms, name string) (string, bool) {
+	if strings.Contains(name, "_") {
+		return name, true
+	}
+	exported := unicode.IsUpper([]rune(name)[0])
+	var b strings.Builder
+	ok := true
+	for i, w := range ins.SplitWords(name) {
+		k, found := ins.Lookup(w)
+		if !found {
+			b.WriteString(w)
+			continue
+		}
+		if w == k || w == strings.ToUpper(k) || (i == 0 && !exported && w == strings.ToLower(k)) {
+			b.WriteString(w)
+			continue
+		}
+		ok = false
+		switch {
+		case i == 0 && !exported:
+			// The initialism at the beginning of an unexported name is all lowercase (e.g. urlPony).
+			k = strings.ToLower(k)
+		case i == 0 && !unicode.IsUpper([]rune(k)[0]):
+			// The initialism at the beginning of an exported name keeps it exported (e.g. IOSVersion).
+			k = strings.ToUpper(k)
+		}
+		b.WriteString(k)
+	}
+	return b.String(), ok
+}
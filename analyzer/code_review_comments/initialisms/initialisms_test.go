@@ -0,0 +1,19 @@
+package initialisms
+
+import (
+	"testing"
+
+	"github.com/gostaticanalysis/testutil"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer is a test for Analyzer.
+func TestAnalyzer(t *testing.T) {
+	td := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, td, Analyzer, "a")
+}
+
+// TestAnalyzerWithSuggestedFixes is a test for suggested fixes of Analyzer.
+func TestAnalyzerWithSuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a")
+}
@@ -0,0 +1,50 @@
+package a
+
+import (
+	"fmt"
+	xmlUrl "net/url" // want "gostyle.initialisms"
+)
+
+const BaseUrl = "https://example.com" // want "gostyle.initialisms"
+
+type UserId int // want "gostyle.initialisms"
+
+var OauthToken, userIDs = "", []UserId{} // want "gostyle.initialisms"
+
+type User struct {
+	Id   UserId // want "gostyle.initialisms"
+	Name string
+	URL  string
+}
+
+type Server interface {
+	ServeHttp() // want "gostyle.initialisms"
+	ServeHTTP()
+}
+
+func (u *User) ApiKey() string { // want "gostyle.initialisms"
+	return fmt.Sprint(u.Id)
+}
+
+func NewUser(userId UserId) *User { // want "gostyle.initialisms"
+	return &User{Id: userId}
+}
+
+func f() {
+	xmlHttpRequest := xmlUrl.URL{} // want "gostyle.initialisms"
+	print(xmlHttpRequest.Host)
+	id := 1
+	urlPony, URLPony, iOS := 1, 2, 3
+	print(id, urlPony, URLPony, iOS)
+	user_id := 1 // left to mixedcaps
+	print(user_id)
+	m := map[string]int{}
+	for jsonKey, idValue := range m {
+		print(jsonKey, idValue)
+	}
+	for k, dbId := range m { // want "gostyle.initialisms"
+		print(k, dbId)
+	}
+	var homeUrl string //nostyle:initialisms
+	print(homeUrl)
+}
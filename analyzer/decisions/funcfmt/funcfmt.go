@@ -6,6 +6,7 @@ import (
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -14,14 +15,22 @@ import (
 
 const (
 	name = "funcfmt"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#function-formatting"
+	url  = "https://google.github.io/styleguide/go/decisions#function-formatting"
+	doc  = "Analyzer based on " + url
 	msgs = "The signature of a function or method declaration should remain on a single line to avoid indentation confusion. (ref: https://google.github.io/styleguide/go/decisions#function-formatting )"
 	msgc = "Function and method calls should not be separated based solely on line length. (ref: https://google.github.io/styleguide/go/decisions#function-formatting )"
 )
 
+// Message kinds.
+const (
+	kindSignature = "signature"
+	kindCall      = "call"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	checkCalls       bool
 )
 
@@ -49,40 +58,30 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Funcfmt.IncludeGenerated
-		checkCalls = c.AnalyzersSettings.Funcfmt.CheckCalls
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Funcfmt{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		CheckCalls:       checkCalls,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
 
-	if disable {
-		return nil, nil
-	}
+func analyze(pass *analysis.Pass, s *config.Settings[config.Funcfmt]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 	}
-	if checkCalls {
+	if s.Analyzer.CheckCalls {
 		nodeFilter = append(nodeFilter, (*ast.CallExpr)(nil))
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	i.Preorder(nodeFilter, func(n ast.Node) {
@@ -97,7 +96,7 @@ func run(pass *analysis.Pass) (any, error) {
 				}
 				for _, id := range f.Names {
 					if pass.Fset.Position(id.Pos()).Line != pass.Fset.Position(nn.Pos()).Line {
-						r.AppendOr(nn.Pos(), nn.End(), msgs)
+						r.AppendOr(nn.Pos(), nn.End(), msgs, reporter.Kind(kindSignature))
 						return
 					}
 				}
@@ -108,18 +107,20 @@ func run(pass *analysis.Pass) (any, error) {
 			}
 			for _, arg := range nn.Args {
 				if pass.Fset.Position(arg.Pos()).Line != pass.Fset.Position(nn.Pos()).Line {
-					r.AppendOr(nn.Pos(), nn.End(), msgc)
+					r.AppendOr(nn.Pos(), nn.End(), msgc, reporter.Kind(kindCall))
 					return
 				}
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url, Kinds: []string{kindSignature, kindCall}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
 	Analyzer.Flags.BoolVar(&checkCalls, "check-calls", false, "check function and method calls in addition to declarations")
 }
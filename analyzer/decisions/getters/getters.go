@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"slices"
-	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
 	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -18,13 +17,15 @@ import (
 
 const (
 	name = "getters"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#getters"
+	url  = "https://google.github.io/styleguide/go/decisions#getters"
+	doc  = "Analyzer based on " + url
 	msg  = "Function and method names should not use a \"Get\" or \"get\" prefix, unless the underlying concept uses the word \"get\" (e.g. an HTTP GET). Prefer starting the name with the noun directly, for example use \"Counts\" over \"GetCounts\". (ref: https://google.github.io/styleguide/go/decisions#getters )"
 )
 
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	exclude          string
 )
 
@@ -52,24 +53,23 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	words := strings.Split(exclude, ",")
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		words = c.AnalyzersSettings.Getters.Exclude
-		includeGenerated = c.AnalyzersSettings.Getters.IncludeGenerated
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Getters{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Exclude:          detector.SplitExcludes(exclude),
 	}
-	if disable {
-		return nil, nil
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Getters]) error {
+	ins := s.Initialisms
+	ex, err := detector.NewExcludes(s.Analyzer.Exclude)
+	if err != nil {
+		return err
 	}
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -79,12 +79,9 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.AssignStmt)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
@@ -95,10 +92,10 @@ func run(pass *analysis.Pass) (any, error) {
 					continue
 				}
 				id := n.Names[i]
-				if slices.Contains(words, id.Name) {
+				if ex.Match(pass.Pkg.Path(), id.Name) {
 					continue
 				}
-				if detector.HasGetPrefix(id.Name) {
+				if ins.HasGetPrefix(id.Name) {
 					r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
 				}
 			}
@@ -108,19 +105,19 @@ func run(pass *analysis.Pass) (any, error) {
 			}
 			for _, field := range n.Methods.List {
 				for _, id := range field.Names {
-					if slices.Contains(words, id.Name) {
+					if ex.Match(pass.Pkg.Path(), id.Name) {
 						continue
 					}
-					if detector.HasGetPrefix(id.Name) {
+					if ins.HasGetPrefix(id.Name) {
 						r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
 					}
 				}
 			}
 		case *ast.FuncDecl:
-			if slices.Contains(words, n.Name.Name) {
+			if ex.Match(pass.Pkg.Path(), n.Name.Name) {
 				return
 			}
-			if detector.HasGetPrefix(n.Name.Name) {
+			if ins.HasGetPrefix(n.Name.Name) {
 				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name))
 			}
 		case *ast.AssignStmt:
@@ -136,21 +133,23 @@ func run(pass *analysis.Pass) (any, error) {
 				if !ok {
 					continue
 				}
-				if slices.Contains(words, id.Name) {
+				if ex.Match(pass.Pkg.Path(), id.Name) {
 					continue
 				}
-				if detector.HasGetPrefix(id.Name) {
+				if ins.HasGetPrefix(id.Name) {
 					r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
 				}
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
-	Analyzer.Flags.StringVar(&exclude, "exclude", "", "exclude words (comma separated)")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&exclude, "exclude", "", detector.ExcludeUsage)
 }
@@ -12,3 +12,16 @@ func TestAnalyzer(t *testing.T) {
 	td := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, td, Analyzer, "a")
 }
+
+func TestAnalyzerExclude(t *testing.T) {
+	if err := Analyzer.Flags.Set("exclude", "getF*,/^GetXXX_/,b/api:GetHTTPStatus"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := Analyzer.Flags.Set("exclude", ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+	td := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, td, Analyzer, "b/...")
+}
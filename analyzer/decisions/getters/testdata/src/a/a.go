@@ -22,3 +22,7 @@ type Getter interface {
 type S struct{}
 
 func (s *S) GetS() {} // want "gostyle.getters"
+
+func Getaway() {}
+
+func GetURL() {} // want "gostyle.getters"
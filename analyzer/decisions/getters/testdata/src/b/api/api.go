@@ -0,0 +1,5 @@
+package api
+
+func GetHTTPStatus() {}
+
+func GetHTTPHeader() {} // want "gostyle.getters"
@@ -0,0 +1,11 @@
+package b
+
+func getSome() {} // want "gostyle.getters"
+
+func getFoo() {}
+
+func GetHTTPStatus() {} // want "gostyle.getters"
+
+type S struct{}
+
+func (s *S) GetXXX_Unrecognized() {}
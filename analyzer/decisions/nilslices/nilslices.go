@@ -8,6 +8,7 @@ import (
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -16,14 +17,22 @@ import (
 
 const (
 	name = "nilslices"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#nil-slices"
+	url  = "https://google.github.io/styleguide/go/decisions#nil-slices"
+	doc  = "Analyzer based on " + url
 	msg  = "If you declare an empty slice as a local variable (especially if it can be the source of a return value), prefer the nil initialization to reduce the risk of bugs by callers. (ref: https://google.github.io/styleguide/go/decisions#nil-slices )"
 	msgc = "When designing interfaces, avoid making a distinction between a nil slice and a non-nil, zero-length slice, as this can lead to subtle programming errors. This is typically accomplished by using len to check for emptiness, rather than == nil. (ref: https://google.github.io/styleguide/go/decisions#nil-slices )"
 )
 
+// Message kinds.
+const (
+	kindDeclaration = "declaration"
+	kindComparison  = "comparison"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 )
 
 // Analyzer based on https://google.github.io/styleguide/go/guide#nil-slices
@@ -50,22 +59,17 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Nilslices.IncludeGenerated
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
+	flags := config.Nilslices{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Nilslices]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -74,12 +78,9 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.BinaryExpr)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
@@ -95,7 +96,7 @@ func run(pass *analysis.Pass) (any, error) {
 				if _, ok := c.Type.(*ast.ArrayType); !ok {
 					continue
 				}
-				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Names[i].Name))
+				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Names[i].Name), reporter.Kind(kindDeclaration))
 			}
 		case *ast.AssignStmt:
 			if n.Tok != token.DEFINE {
@@ -116,7 +117,7 @@ func run(pass *analysis.Pass) (any, error) {
 				if !ok {
 					continue
 				}
-				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
+				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, id.Name), reporter.Kind(kindDeclaration))
 			}
 		case *ast.BinaryExpr:
 			if n.Op != token.EQL && n.Op != token.NEQ {
@@ -132,29 +133,31 @@ func run(pass *analysis.Pass) (any, error) {
 				idx, okx := n.X.(*ast.Ident)
 				idy, oky := n.Y.(*ast.Ident)
 				if okx && oky {
-					r.Append(n.Pos(), fmt.Sprintf("%s: %s %s %s", msgc, idx.Name, n.Op.String(), idy.Name))
+					r.Append(n.Pos(), fmt.Sprintf("%s: %s %s %s", msgc, idx.Name, n.Op.String(), idy.Name), reporter.Kind(kindComparison))
 				} else {
-					r.Append(n.Pos(), msgc)
+					r.Append(n.Pos(), msgc, reporter.Kind(kindComparison))
 				}
 			}
 			if isNil(pass, n.X) && isSlice(pass, n.Y) {
 				idx, okx := n.X.(*ast.Ident)
 				idy, oky := n.Y.(*ast.Ident)
 				if okx && oky {
-					r.Append(n.Pos(), fmt.Sprintf("%s: %s %s %s", msgc, idx.Name, n.Op.String(), idy.Name))
+					r.Append(n.Pos(), fmt.Sprintf("%s: %s %s %s", msgc, idx.Name, n.Op.String(), idy.Name), reporter.Kind(kindComparison))
 				} else {
-					r.Append(n.Pos(), msgc)
+					r.Append(n.Pos(), msgc, reporter.Kind(kindComparison))
 				}
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url, Kinds: []string{kindDeclaration, kindComparison}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
 }
 
 func isSlice(pass *analysis.Pass, e ast.Expr) bool {
@@ -12,3 +12,12 @@ func TestAnalyzer(t *testing.T) {
 	td := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, td, Analyzer, "a")
 }
+
+func TestAnalyzerExcludeTest(t *testing.T) {
+	excludeTest = true
+	t.Cleanup(func() {
+		excludeTest = false
+	})
+	td := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, td, Analyzer, "b")
+}
@@ -0,0 +1,3 @@
+package b
+
+var s = []string{} // want "gostyle.nilslices"
@@ -0,0 +1,14 @@
+package b
+
+import "testing"
+
+func TestB(t *testing.T) {
+	s := []string{}
+	t.Log(s)
+	var s2 []string
+	if s2 == nil {
+		t.Log(s2)
+	}
+	s3 := []string{} //nostyle:nilslices
+	t.Log(s3)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -16,14 +17,22 @@ import (
 
 const (
 	name = "pkgnames"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#package-names"
+	url  = "https://google.github.io/styleguide/go/decisions#package-names"
+	doc  = "Analyzer based on " + url
 	msg  = "Go package names should be short and contain only lowercase letters. A package name composed of multiple words should be left unbroken in all lowercase. (ref: https://google.github.io/styleguide/go/decisions#package-names )"
 	msg2 = "Avoid uninformative package names like util, utility, common, helper, and so on. (ref: https://google.github.io/styleguide/go/decisions#package-names )"
 )
 
+// Message kinds.
+const (
+	kindCase          = "case"
+	kindUninformative = "uninformative"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	uninformatives   = []string{
 		"util",
 		"utility",
@@ -56,22 +65,17 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Pkgnames.IncludeGenerated
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
+	flags := config.Pkgnames{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Pkgnames]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -79,12 +83,9 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.ImportSpec)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	i.Preorder(nodeFilter, func(n ast.Node) {
@@ -101,20 +102,22 @@ func run(pass *analysis.Pass) (any, error) {
 			return
 		}
 		if strings.Contains(strings.TrimSuffix(pkgname, "_test"), "_") {
-			r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, pkgname))
+			r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, pkgname), reporter.Kind(kindCase))
 		}
 		if strings.ToLower(pkgname) != pkgname {
-			r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, pkgname))
+			r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, pkgname), reporter.Kind(kindCase))
 		}
 		if slices.Contains(uninformatives, strings.ToLower(pkgname)) {
-			r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg2, pkgname))
+			r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg2, pkgname), reporter.Kind(kindUninformative))
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url, Kinds: []string{kindCase, kindUninformative}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -15,15 +16,23 @@ import (
 
 const (
 	name = "recvnames"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#receiver-names"
+	url  = "https://google.github.io/styleguide/go/decisions#receiver-names"
+	doc  = "Analyzer based on " + url
 	msg  = "Receiver variable names must be short (usually one or two letters in length) (ref: https://google.github.io/styleguide/go/decisions#receiver-names )"
 	msgm = "Receiver variable name length should be less than or equal to %d. (THIS IS NOT IN Go Style): %s"
 	msga = "Receiver variable names must be abbreviations for the type itself. (ref: https://google.github.io/styleguide/go/decisions#receiver-names )"
 )
 
+// Message kinds.
+const (
+	kindLength       = "length"
+	kindAbbreviation = "abbreviation"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	max              int
 )
 
@@ -51,36 +60,27 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Recvnames.IncludeGenerated
-		max = c.AnalyzersSettings.Recvnames.Max
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Recvnames{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Max:              max,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
 
-	if disable {
-		return nil, nil
-	}
+func analyze(pass *analysis.Pass, s *config.Settings[config.Recvnames]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	i.Preorder(nodeFilter, func(n ast.Node) {
@@ -107,16 +107,16 @@ func run(pass *analysis.Pass) (any, error) {
 				}
 				sn = strings.ToLower(sn)
 				for _, n := range l.Names {
-					if len(n.Name) > max {
-						if max == config.DefaultReceiverNameMax {
-							r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name))
+					if len(n.Name) > s.Analyzer.Max {
+						if s.Analyzer.Max == config.DefaultReceiverNameMax {
+							r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name), reporter.Kind(kindLength))
 						} else {
-							r.Append(n.Pos(), fmt.Sprintf(msgm, max, n.Name))
+							r.Append(n.Pos(), fmt.Sprintf(msgm, s.Analyzer.Max, n.Name), reporter.Kind(kindLength))
 						}
 					}
 					for _, c := range n.Name {
 						if !strings.ContainsRune(sn, c) {
-							r.Append(n.Pos(), fmt.Sprintf("%s: %s", msga, n.Name))
+							r.Append(n.Pos(), fmt.Sprintf("%s: %s", msga, n.Name), reporter.Kind(kindAbbreviation))
 							return
 						}
 					}
@@ -125,11 +125,13 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url, Kinds: []string{kindLength, kindAbbreviation}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
 	Analyzer.Flags.IntVar(&max, "max", config.DefaultReceiverNameMax, "max receiver name length")
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -15,14 +16,22 @@ import (
 
 const (
 	name = "recvtype"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#receiver-type"
+	url  = "https://google.github.io/styleguide/go/decisions#receiver-type"
+	doc  = "Analyzer based on " + url
 	msg  = "When in doubt, use a pointer receiver. (GOSTYLE MEMO: It's a strong check, so read Go Style and decide if it should be ignored or not proactively) (ref: https://google.github.io/styleguide/go/decisions#receiver-type )"
 	msgm = "If the receiver is a map, function, or channel, use a value rather than a pointer. (ref: https://google.github.io/styleguide/go/decisions#receiver-type )"
 )
 
+// Message kinds.
+const (
+	kindPointer = "pointer"
+	kindValue   = "value"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 )
 
 // Analyzer based on https://google.github.io/styleguide/go/decisions#receiver-type
@@ -49,35 +58,26 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Recvtype.IncludeGenerated
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Recvtype{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
 
-	if disable {
-		return nil, nil
-	}
+func analyze(pass *analysis.Pass, s *config.Settings[config.Recvtype]) error {
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	i.Preorder(nodeFilter, func(n ast.Node) {
@@ -94,13 +94,13 @@ func run(pass *analysis.Pass) (any, error) {
 						return
 					}
 					if _, ok := typ.Underlying().(*types.Map); ok {
-						r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgm, f.Names[0].Name))
+						r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgm, f.Names[0].Name), reporter.Kind(kindValue))
 					}
 					if _, ok := typ.Underlying().(*types.Signature); ok {
-						r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgm, f.Names[0].Name))
+						r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgm, f.Names[0].Name), reporter.Kind(kindValue))
 					}
 					if _, ok := typ.Underlying().(*types.Chan); ok {
-						r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgm, f.Names[0].Name))
+						r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgm, f.Names[0].Name), reporter.Kind(kindValue))
 					}
 				case *ast.Ident:
 					typ := pass.TypesInfo.TypeOf(f.Type)
@@ -115,16 +115,18 @@ func run(pass *analysis.Pass) (any, error) {
 							return
 						}
 					}
-					r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name))
+					r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name), reporter.Kind(kindPointer))
 				}
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url, Kinds: []string{kindPointer, kindValue}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
 }
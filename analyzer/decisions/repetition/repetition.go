@@ -5,12 +5,12 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
-	"slices"
 	"strings"
 
-	"github.com/fatih/camelcase"
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -19,14 +19,22 @@ import (
 
 const (
 	name = "repetition"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#repetition"
+	url  = "https://google.github.io/styleguide/go/decisions#repetition"
+	doc  = "Analyzer based on " + url
 	msgp = "When naming exported symbols, the name of the package is always visible outside your package, so redundant information between the two should be reduced or eliminated. (ref: https://google.github.io/styleguide/go/decisions#package-vs-exported-symbol-name )"
 	msgt = "The compiler always knows the type of a variable, and in most cases it is also clear to the reader what type a variable is by how it is used. It is only necessary to clarify the type of a variable if its value appears twice in the same scope. (ref: https://google.github.io/styleguide/go/decisions#variable-name-vs-type )"
 )
 
+// Message kinds.
+const (
+	kindPackage = "package"
+	kindType    = "type"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	exclude          string
 )
 
@@ -54,25 +62,23 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	words := strings.Split(exclude, ",")
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Recvnames.IncludeGenerated
-		words = c.AnalyzersSettings.Repetition.Exclude
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Repetition{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Exclude:          detector.SplitExcludes(exclude),
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
 
-	if disable {
-		return nil, nil
+func analyze(pass *analysis.Pass, s *config.Settings[config.Repetition]) error {
+	ins := s.Initialisms
+	ex, err := detector.NewExcludes(s.Analyzer.Exclude)
+	if err != nil {
+		return err
 	}
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -82,18 +88,15 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.FuncDecl)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	tr := &typeVarReporter{
 		r:       r,
 		pass:    pass,
-		exclude: words,
+		exclude: ex,
 	}
 	pkgn := pass.Pkg.Name()
 	i.Preorder(nodeFilter, func(n ast.Node) {
@@ -108,8 +111,8 @@ func run(pass *analysis.Pass) (any, error) {
 				if !id.IsExported() {
 					continue
 				}
-				splitted := camelcase.Split(id.Name)
-				if slices.Contains(words, id.Name) {
+				splitted := ins.SplitWords(id.Name)
+				if ex.Match(pass.Pkg.Path(), id.Name) {
 					continue
 				}
 				for _, s := range splitted {
@@ -120,7 +123,7 @@ func run(pass *analysis.Pass) (any, error) {
 						continue
 					}
 					if strings.Contains(pkgn, strings.ToLower(s)) {
-						r.Append(n.Pos(), fmt.Sprintf("%s: %s<-[%s]->%s", msgp, pkgn, s, id.Name))
+						r.Append(n.Pos(), fmt.Sprintf("%s: %s<-[%s]->%s", msgp, pkgn, s, id.Name), reporter.Kind(kindPackage))
 					}
 				}
 			}
@@ -151,7 +154,7 @@ func run(pass *analysis.Pass) (any, error) {
 			if !n.Name.IsExported() {
 				return
 			}
-			if slices.Contains(words, n.Name.Name) {
+			if ex.Match(pass.Pkg.Path(), n.Name.Name) {
 				return
 			}
 			if strings.HasPrefix(n.Name.Name, "Test") {
@@ -175,7 +178,7 @@ func run(pass *analysis.Pass) (any, error) {
 			}
 
 			// Package vs. exported symbol name
-			splitted := camelcase.Split(n.Name.Name)
+			splitted := ins.SplitWords(n.Name.Name)
 			for _, s := range splitted {
 				if len(s) == 1 {
 					continue
@@ -184,29 +187,31 @@ func run(pass *analysis.Pass) (any, error) {
 					continue
 				}
 				if strings.Contains(pkgn, strings.ToLower(s)) {
-					r.Append(n.Pos(), fmt.Sprintf("%s: %s<-[%s]->%s", msgp, pkgn, s, n.Name.Name))
+					r.Append(n.Pos(), fmt.Sprintf("%s: %s<-[%s]->%s", msgp, pkgn, s, n.Name.Name), reporter.Kind(kindPackage))
 				}
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url, Kinds: []string{kindPackage, kindType}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
-	Analyzer.Flags.StringVar(&exclude, "exclude", "", "exclude words (comma separated)")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&exclude, "exclude", "", detector.ExcludeUsage)
 }
 
 type typeVarReporter struct {
 	r       *reporter.Reporter
 	pass    *analysis.Pass
-	exclude []string
+	exclude *detector.Excludes
 }
 
 func (tr *typeVarReporter) report(pos token.Pos, varname string) {
-	if slices.Contains(tr.exclude, varname) {
+	if tr.exclude.Match(tr.pass.Pkg.Path(), varname) {
 		return
 	}
 	// Variable name vs. type.
@@ -223,35 +228,35 @@ func (tr *typeVarReporter) report(pos token.Pos, varname string) {
 		switch o.Type() {
 		case types.Typ[types.Int], types.Typ[types.Int8], types.Typ[types.Int16], types.Typ[types.Int32], types.Typ[types.Int64]:
 			if strings.Contains(strings.ToLower(varname), "int") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "int", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "int", o.Type().String()), reporter.Kind(kindType))
 			} else if strings.Contains(strings.ToLower(varname), "num") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "num", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "num", o.Type().String()), reporter.Kind(kindType))
 			}
 		case types.Typ[types.Uint], types.Typ[types.Uint8], types.Typ[types.Uint16], types.Typ[types.Uint32], types.Typ[types.Uint64]:
 			if strings.Contains(strings.ToLower(varname), "uint") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "uint", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "uint", o.Type().String()), reporter.Kind(kindType))
 			} else if strings.Contains(strings.ToLower(varname), "num") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "num", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "num", o.Type().String()), reporter.Kind(kindType))
 			}
 		case types.Typ[types.Float32], types.Typ[types.Float64]:
 			if strings.Contains(strings.ToLower(varname), "float") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "float", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "float", o.Type().String()), reporter.Kind(kindType))
 			} else if strings.Contains(strings.ToLower(varname), "num") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "num", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "num", o.Type().String()), reporter.Kind(kindType))
 			}
 		case types.Typ[types.String]:
 			if strings.Contains(strings.ToLower(varname), "string") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "string", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "string", o.Type().String()), reporter.Kind(kindType))
 			} else if strings.Contains(strings.ToLower(varname), "str") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "str", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "str", o.Type().String()), reporter.Kind(kindType))
 			}
 		case types.Typ[types.Bool]:
 			if strings.Contains(strings.ToLower(varname), "bool") {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "bool", o.Type().String()))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, varname, "bool", o.Type().String()), reporter.Kind(kindType))
 			}
 		default:
 			if strings.Contains(strings.ToLower(varname), strings.ToLower(o.Type().String())) {
-				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, tr.pass.Pkg.Name(), o.Type().String(), varname))
+				tr.r.Append(pos, fmt.Sprintf("%s: %s<-[%s]->%s", msgt, tr.pass.Pkg.Name(), o.Type().String(), varname), reporter.Kind(kindType))
 			}
 		}
 	}
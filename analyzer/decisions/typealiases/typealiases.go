@@ -3,11 +3,11 @@ package typealiases
 import (
 	"fmt"
 	"go/ast"
-	"slices"
-	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -16,13 +16,15 @@ import (
 
 const (
 	name = "typealiases"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#type-aliases"
+	url  = "https://google.github.io/styleguide/go/decisions#type-aliases"
+	doc  = "Analyzer based on " + url
 	msg  = "Type aliases are rare; their primary use is to aid migrating packages to new source code locations. Don’t use type aliasing when it is not needed (ref: https://google.github.io/styleguide/go/decisions#type-aliases )"
 )
 
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	exclude          string
 )
 
@@ -50,41 +52,36 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	words := strings.Split(exclude, ",")
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		words = c.AnalyzersSettings.Typealiases.Exclude
-		includeGenerated = c.AnalyzersSettings.Typealiases.IncludeGenerated
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Typealiases{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Exclude:          detector.SplitExcludes(exclude),
 	}
-	if disable {
-		return nil, nil
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Typealiases]) error {
+	ex, err := detector.NewExcludes(s.Analyzer.Exclude)
+	if err != nil {
+		return err
 	}
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
 		(*ast.TypeSpec)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
 		case *ast.TypeSpec:
-			if slices.Contains(words, n.Name.Name) {
+			if ex.Match(pass.Pkg.Path(), n.Name.Name) {
 				return
 			}
 			if n.Assign != 0 {
@@ -93,11 +90,13 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
-	Analyzer.Flags.StringVar(&exclude, "exclude", "", "exclude words (comma separated)")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&exclude, "exclude", "", detector.ExcludeUsage)
 }
@@ -0,0 +1,7 @@
+//nostyle:file:underscores
+
+package a
+
+const B_MAX_LENGTH = 10
+
+func b_a() {}
@@ -0,0 +1,31 @@
+package a
+
+//nostyle:underscores
+func c_a() {
+	var c_b int
+	print(c_b)
+}
+
+const (
+	//nostyle:underscores
+	C_MAX_LENGTH = 10
+	C_MIN_LENGTH = 1 // want "gostyle.underscores"
+)
+
+//nostyle:begin underscores
+const C_A = 1
+
+var c_c = 1
+
+//nostyle:end
+
+var c_d = 1 // want "gostyle.underscores"
+
+//nostyle:begin mixedcaps
+var c_e = 1 // want "gostyle.underscores"
+//nostyle:end
+
+//nostyle:begin all
+var c_f = 1
+
+//nostyle:end
@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"slices"
 	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
 	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/fixer"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -18,13 +19,15 @@ import (
 
 const (
 	name = "underscores"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#underscores"
+	url  = "https://google.github.io/styleguide/go/decisions#underscores"
+	doc  = "Analyzer based on " + url
 	msg  = "Names in Go should in general not contain underscores. (however) there are three exceptions to this principle. (ref: https://google.github.io/styleguide/go/decisions#underscores )"
 )
 
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	exclude          string
 )
 
@@ -52,24 +55,23 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	words := strings.Split(exclude, ",")
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		words = c.AnalyzersSettings.Underscores.Exclude
-		includeGenerated = c.AnalyzersSettings.Underscores.IncludeGenerated
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+	flags := config.Underscores{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		Exclude:          detector.SplitExcludes(exclude),
 	}
-	if disable {
-		return nil, nil
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Underscores]) error {
+	ins := s.Initialisms
+	ex, err := detector.NewExcludes(s.Analyzer.Exclude)
+	if err != nil {
+		return err
 	}
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -83,18 +85,15 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.RangeStmt)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
 		case *ast.File:
 			pkg := n.Name.Name
-			if slices.Contains(words, pkg) {
+			if ex.Match(pass.Pkg.Path(), pkg) {
 				return
 			}
 			if !detector.NoUnderscore(strings.TrimSuffix(pkg, "_test")) {
@@ -104,27 +103,27 @@ func run(pass *analysis.Pass) (any, error) {
 			if n.Name == nil {
 				return
 			}
-			if slices.Contains(words, n.Name.Name) {
+			if ex.Match(pass.Pkg.Path(), n.Name.Name) {
 				return
 			}
 			if !detector.NoUnderscore(n.Name.Name) {
-				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name))
+				r.AppendWithFixes(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name), fixes(pass, ins, n.Name)...)
 			}
 		case *ast.ValueSpec:
 			for _, id := range n.Names {
-				if slices.Contains(words, id.Name) {
+				if ex.Match(pass.Pkg.Path(), id.Name) {
 					continue
 				}
 				if !detector.NoUnderscore(id.Name) {
-					r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
+					r.AppendWithFixes(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name), fixes(pass, ins, id)...)
 				}
 			}
 		case *ast.TypeSpec:
-			if slices.Contains(words, n.Name.Name) {
+			if ex.Match(pass.Pkg.Path(), n.Name.Name) {
 				return
 			}
 			if !detector.NoUnderscore(n.Name.Name) {
-				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name))
+				r.AppendWithFixes(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name), fixes(pass, ins, n.Name)...)
 			}
 		case *ast.InterfaceType:
 			if n.Methods == nil {
@@ -132,23 +131,23 @@ func run(pass *analysis.Pass) (any, error) {
 			}
 			for _, field := range n.Methods.List {
 				for _, id := range field.Names {
-					if slices.Contains(words, id.Name) {
+					if ex.Match(pass.Pkg.Path(), id.Name) {
 						continue
 					}
 					if !detector.NoUnderscore(id.Name) {
-						r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
+						r.AppendWithFixes(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name), fixes(pass, ins, id)...)
 					}
 				}
 			}
 		case *ast.FuncDecl:
-			if !slices.Contains(words, n.Name.Name) {
+			if !ex.Match(pass.Pkg.Path(), n.Name.Name) {
 				f := pass.Fset.File(n.End())
 				// Test, Benchmark and Example function names within *_test.go files may include underscores.
 				if strings.HasSuffix(f.Name(), "_test.go") {
 					return
 				}
 				if !detector.NoUnderscore(n.Name.Name) {
-					r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name))
+					r.AppendWithFixes(n.Pos(), fmt.Sprintf("%s: %s", msg, n.Name.Name), fixes(pass, ins, n.Name)...)
 				}
 			}
 			if n.Recv == nil {
@@ -156,11 +155,11 @@ func run(pass *analysis.Pass) (any, error) {
 			}
 			for _, field := range n.Recv.List {
 				for _, id := range field.Names {
-					if slices.Contains(words, id.Name) {
+					if ex.Match(pass.Pkg.Path(), id.Name) {
 						continue
 					}
 					if !detector.NoUnderscore(id.Name) {
-						r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
+						r.AppendWithFixes(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name), fixes(pass, ins, id)...)
 					}
 				}
 			}
@@ -173,30 +172,41 @@ func run(pass *analysis.Pass) (any, error) {
 				if !ok {
 					continue
 				}
-				if slices.Contains(words, id.Name) {
+				if ex.Match(pass.Pkg.Path(), id.Name) {
 					continue
 				}
 				if !detector.NoUnderscore(id.Name) {
-					r.Append(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name))
+					r.AppendWithFixes(id.Pos(), fmt.Sprintf("%s: %s", msg, id.Name), fixes(pass, ins, id)...)
 				}
 			}
 		case *ast.RangeStmt:
 			idk, ok := n.Key.(*ast.Ident)
-			if ok && !slices.Contains(words, idk.Name) && !detector.NoUnderscore(idk.Name) {
-				r.Append(idk.Pos(), fmt.Sprintf("%s: %s", msg, idk.Name))
+			if ok && !ex.Match(pass.Pkg.Path(), idk.Name) && !detector.NoUnderscore(idk.Name) {
+				r.AppendWithFixes(idk.Pos(), fmt.Sprintf("%s: %s", msg, idk.Name), fixes(pass, ins, idk)...)
 			}
 			idv, ok := n.Value.(*ast.Ident)
-			if ok && !slices.Contains(words, idv.Name) && !detector.NoUnderscore(idv.Name) {
-				r.Append(idv.Pos(), fmt.Sprintf("%s: %s", msg, idv.Name))
+			if ok && !ex.Match(pass.Pkg.Path(), idv.Name) && !detector.NoUnderscore(idv.Name) {
+				r.AppendWithFixes(idv.Pos(), fmt.Sprintf("%s: %s", msg, idv.Name), fixes(pass, ins, idv)...)
 			}
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
-	Analyzer.Flags.StringVar(&exclude, "exclude", "", "exclude words (comma separated)")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&exclude, "exclude", "", detector.ExcludeUsage)
+}
+
+// fixes returns suggested fixes that rename id to MixedCaps.
+func fixes(pass *analysis.Pass, ins *detector.Initialisms, id *ast.Ident) []analysis.SuggestedFix {
+	to := ins.MixedCaps(id.Name)
+	if !detector.NoUnderscore(to) {
+		return nil
+	}
+	return fixer.Rename(pass, id, to)
 }
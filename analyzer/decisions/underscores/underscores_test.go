@@ -12,3 +12,8 @@ func TestAnalyzer(t *testing.T) {
 	td := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, td, Analyzer, "a")
 }
+
+// TestAnalyzerWithSuggestedFixes is a test for suggested fixes of Analyzer.
+func TestAnalyzerWithSuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a")
+}
@@ -5,11 +5,11 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
-	"slices"
-	"strings"
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -18,7 +18,8 @@ import (
 
 const (
 	name = "varnames"
-	doc  = "Analyzer based on https://google.github.io/styleguide/go/decisions#variable-names"
+	url  = "https://google.github.io/styleguide/go/decisions#variable-names"
+	doc  = "Analyzer based on " + url
 )
 
 const (
@@ -32,6 +33,7 @@ var (
 	disable             bool
 	exclude             string
 	includeGenerated    bool
+	excludeTest         bool
 	smallScopeMax       int
 	smallVarnameMax     int
 	mediumScopeMax      int
@@ -65,35 +67,33 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
+	flags := config.Varnames{
+		Excludes:            config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated:    includeGenerated,
+		Exclude:             detector.SplitExcludes(exclude),
+		SmallScopeMax:       smallScopeMax,
+		SmallVarnameMax:     smallVarnameMax,
+		MediumScopeMax:      mediumScopeMax,
+		MediumVarnameMax:    mediumVarnameMax,
+		LargeScopeMax:       largeScopeMax,
+		LargeVarnameMax:     largeVarnameMax,
+		VeryLargeVarnameMax: veryLargeVarnameMax,
+	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Varnames]) error {
+	ex, err := detector.NewExcludes(s.Analyzer.Exclude)
 	if err != nil {
-		return nil, err
-	}
-	words := strings.Split(exclude, ",")
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		words = c.AnalyzersSettings.Varnames.Exclude
-		includeGenerated = c.AnalyzersSettings.Varnames.IncludeGenerated
-		smallScopeMax = c.AnalyzersSettings.Varnames.SmallScopeMax
-		smallVarnameMax = c.AnalyzersSettings.Varnames.SmallVarnameMax
-		mediumScopeMax = c.AnalyzersSettings.Varnames.MediumScopeMax
-		mediumVarnameMax = c.AnalyzersSettings.Varnames.MediumVarnameMax
-		largeScopeMax = c.AnalyzersSettings.Varnames.LargeScopeMax
-		largeVarnameMax = c.AnalyzersSettings.Varnames.LargeVarnameMax
-		veryLargeVarnameMax = c.AnalyzersSettings.Varnames.VeryLargeVarnameMax
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
+		return err
 	}
-	if disable {
-		return nil, nil
-	}
-	if smallVarnameMax <= 0 && mediumVarnameMax <= 0 && largeVarnameMax <= 0 && veryLargeVarnameMax <= 0 {
-		return nil, nil
+	if s.Analyzer.SmallVarnameMax <= 0 && s.Analyzer.MediumVarnameMax <= 0 && s.Analyzer.LargeVarnameMax <= 0 && s.Analyzer.VeryLargeVarnameMax <= 0 {
+		return nil
 	}
 
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -103,18 +103,22 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.RangeStmt)(nil),
 	}
 
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	sr := &scopeReporter{
-		r:       r,
-		pass:    pass,
-		exclude: words,
+		r:                   r,
+		pass:                pass,
+		exclude:             ex,
+		smallScopeMax:       s.Analyzer.SmallScopeMax,
+		smallVarnameMax:     s.Analyzer.SmallVarnameMax,
+		mediumScopeMax:      s.Analyzer.MediumScopeMax,
+		mediumVarnameMax:    s.Analyzer.MediumVarnameMax,
+		largeScopeMax:       s.Analyzer.LargeScopeMax,
+		largeVarnameMax:     s.Analyzer.LargeVarnameMax,
+		veryLargeVarnameMax: s.Analyzer.VeryLargeVarnameMax,
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
@@ -145,17 +149,24 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 type scopeReporter struct {
-	r       *reporter.Reporter
-	pass    *analysis.Pass
-	exclude []string
+	r                   *reporter.Reporter
+	pass                *analysis.Pass
+	exclude             *detector.Excludes
+	smallScopeMax       int
+	smallVarnameMax     int
+	mediumScopeMax      int
+	mediumVarnameMax    int
+	largeScopeMax       int
+	largeVarnameMax     int
+	veryLargeVarnameMax int
 }
 
 func (sr *scopeReporter) report(pos token.Pos, varname string) {
-	if slices.Contains(sr.exclude, varname) {
+	if sr.exclude.Match(sr.pass.Pkg.Path(), varname) {
 		return
 	}
 	s := sr.pass.Pkg.Scope().Innermost(pos)
@@ -170,20 +181,20 @@ func (sr *scopeReporter) report(pos token.Pos, varname string) {
 	case *types.Var, *types.Const:
 		switch sr.scope(s) {
 		case scopeSmall:
-			if smallVarnameMax > 0 && len(varname) > smallVarnameMax {
-				sr.r.Append(pos, fmt.Sprintf("%q is small scope. Variable name length of small scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, smallVarnameMax))
+			if sr.smallVarnameMax > 0 && len(varname) > sr.smallVarnameMax {
+				sr.r.Append(pos, fmt.Sprintf("%q is small scope. Variable name length of small scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, sr.smallVarnameMax))
 			}
 		case scopeMedium:
-			if mediumVarnameMax > 0 && len(varname) > mediumVarnameMax {
-				sr.r.Append(pos, fmt.Sprintf("%q is medium scope. Variable name length of medium scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, mediumVarnameMax))
+			if sr.mediumVarnameMax > 0 && len(varname) > sr.mediumVarnameMax {
+				sr.r.Append(pos, fmt.Sprintf("%q is medium scope. Variable name length of medium scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, sr.mediumVarnameMax))
 			}
 		case scopeLarge:
-			if largeVarnameMax > 0 && len(varname) > largeVarnameMax {
-				sr.r.Append(pos, fmt.Sprintf("%q is large scope. Variable name length of large scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, largeVarnameMax))
+			if sr.largeVarnameMax > 0 && len(varname) > sr.largeVarnameMax {
+				sr.r.Append(pos, fmt.Sprintf("%q is large scope. Variable name length of large scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, sr.largeVarnameMax))
 			}
 		case scopeVeryLarge:
-			if veryLargeVarnameMax > 0 && len(varname) > veryLargeVarnameMax {
-				sr.r.Append(pos, fmt.Sprintf("%q is very large scope. Variable name length of very large scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, veryLargeVarnameMax))
+			if sr.veryLargeVarnameMax > 0 && len(varname) > sr.veryLargeVarnameMax {
+				sr.r.Append(pos, fmt.Sprintf("%q is very large scope. Variable name length of very large scope should be less than or equal to %d. (THIS IS NOT IN Go Style)", varname, sr.veryLargeVarnameMax))
 			}
 		}
 	}
@@ -196,22 +207,24 @@ func (sr *scopeReporter) scope(s *types.Scope) int {
 		return scopeVeryLarge
 	}
 	scope := end - start
-	if scope <= smallScopeMax {
+	if scope <= sr.smallScopeMax {
 		return scopeSmall
 	}
-	if scope <= mediumScopeMax {
+	if scope <= sr.mediumScopeMax {
 		return scopeMedium
 	}
-	if scope <= largeScopeMax {
+	if scope <= sr.largeScopeMax {
 		return scopeLarge
 	}
 	return scopeVeryLarge
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceDecisions, URL: url})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
-	Analyzer.Flags.StringVar(&exclude, "exclude", "", "exclude words (comma separated)")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.StringVar(&exclude, "exclude", "", detector.ExcludeUsage)
 	Analyzer.Flags.IntVar(&smallScopeMax, "small-scope-max", config.DefaultSmallScopeMax, "max lines for small scope")
 	Analyzer.Flags.IntVar(&smallVarnameMax, "small-varname-max", config.DefaultSmallVarnameMax, "max length of variable name for small scope")
 	Analyzer.Flags.IntVar(&mediumScopeMax, "medium-scope-max", config.DefaultMediumScopeMax, "max lines for medium scope")
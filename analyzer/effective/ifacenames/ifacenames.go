@@ -7,6 +7,8 @@ import (
 
 	"github.com/gostaticanalysis/comment/passes/commentmap"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/k1LoW/gostyle/reporter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -15,14 +17,22 @@ import (
 
 const (
 	name = "ifacenames"
-	doc  = "Analyzer based on https://go.dev/doc/effective_go#interface-names."
+	url  = "https://go.dev/doc/effective_go#interface-names"
+	doc  = "Analyzer based on " + url + "."
 	msg  = "By convention, one-method interfaces are named by the method name plus an -er suffix or similar modification to construct an agent noun. (ref: https://go.dev/doc/effective_go#interface-names )"
 	msgc = "All interface names with the -er suffix are required. (THIS IS NOT IN Effective Go)"
 )
 
+// Message kinds.
+const (
+	kindSingleMethod = "single-method"
+	kindAll          = "all"
+)
+
 var (
 	disable          bool
 	includeGenerated bool
+	excludeTest      bool
 	all              bool
 )
 
@@ -52,23 +62,19 @@ var AnalyzerWithConfig = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	c, err := config.Load(pass)
-	if err != nil {
-		return nil, err
-	}
-	var opts []reporter.Option
-	if c != nil {
-		disable = c.IsDisabled(name)
-		includeGenerated = c.AnalyzersSettings.Ifacenames.IncludeGenerated
-		all = c.AnalyzersSettings.Ifacenames.All
-		opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles))
-	}
-	if disable {
-		return nil, nil
+	flags := config.Ifacenames{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+		All:              all,
 	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Ifacenames]) error {
+	ins := s.Initialisms
 	i, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
+		return fmt.Errorf("unexpected result type from inspect: %T", pass.ResultOf[inspect.Analyzer])
 	}
 
 	nodeFilter := []ast.Node{
@@ -77,25 +83,22 @@ func run(pass *analysis.Pass) (any, error) {
 	}
 
 	var ii *ast.Ident
-	if includeGenerated {
-		opts = append(opts, reporter.IncludeGenerated())
-	}
-	r, err := reporter.New(name, pass, opts...)
+	r, err := reporter.New(name, pass, s.Options...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	i.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
 		case *ast.InterfaceType:
 			if len(n.Methods.List) == 1 && len(n.Methods.List[0].Names) > 0 {
 				mn := n.Methods.List[0].Names[0].Name
-				if !strings.HasPrefix(ii.Name, mn[:len(mn)-1]) || (!strings.HasSuffix(ii.Name, "er") && !strings.HasSuffix(ii.Name, "or")) { // huristic
-					r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, ii.Name))
+				if !derived(ins, ii.Name, mn) || !agentNoun(ins, ii.Name) { // huristic
+					r.Append(n.Pos(), fmt.Sprintf("%s: %s", msg, ii.Name), reporter.Kind(kindSingleMethod))
 					return
 				}
 			}
-			if all && !strings.HasSuffix(ii.Name, "er") && !strings.HasSuffix(ii.Name, "or") {
-				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgc, ii.Name))
+			if s.Analyzer.All && !agentNoun(ins, ii.Name) {
+				r.Append(n.Pos(), fmt.Sprintf("%s: %s", msgc, ii.Name), reporter.Kind(kindAll))
 				return
 			}
 		case *ast.Ident:
@@ -103,11 +106,42 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	})
 	r.Report()
-	return nil, nil
+	return nil
 }
 
 func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceEffective, URL: url, Kinds: []string{kindSingleMethod, kindAll}})
 	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
 	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
 	Analyzer.Flags.BoolVar(&all, "all", false, "all interface names with the -er suffix are required")
 }
+
+// agentNoun reports whether the last word of the interface name has the -er (or -or) suffix (e.g. Reader, Validator).
+func agentNoun(ins *detector.Initialisms, iface string) bool {
+	ws := ins.SplitWords(iface)
+	if len(ws) == 0 {
+		return false
+	}
+	w := ws[len(ws)-1]
+	return strings.HasSuffix(w, "er") || strings.HasSuffix(w, "or")
+}
+
+// derived reports whether the interface name is made from the method name word by word (e.g. Reader from Read, HTTPServer from HTTPServe).
+// The last word of the method name may lose its last letter (e.g. Closer from Close).
+func derived(ins *detector.Initialisms, iface, method string) bool {
+	iw := ins.SplitWords(iface)
+	mw := ins.SplitWords(method)
+	if len(mw) == 0 || len(iw) < len(mw) {
+		return false
+	}
+	for j, w := range mw {
+		if j == len(mw)-1 {
+			w = string([]rune(w)[:len([]rune(w))-1])
+		}
+		if !strings.HasPrefix(iw[j], w) {
+			return false
+		}
+	}
+	return true
+}
@@ -15,6 +15,7 @@ func TestAnalyzer(t *testing.T) {
 	}{
 		{false, "a"},
 		{true, "b"},
+		{false, "c"},
 	}
 	for _, tt := range tests {
 		all = tt.all
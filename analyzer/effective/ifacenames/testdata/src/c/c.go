@@ -0,0 +1,25 @@
+package c
+
+type Reader interface {
+	Read() error
+}
+
+type Closer interface {
+	Close() error
+}
+
+type Reads interface { // want "gostyle.ifacenames"
+	Read() error
+}
+
+type JSONMarshaler interface {
+	JSONMarshal() ([]byte, error)
+}
+
+type HTTPHandler interface { // want "gostyle.ifacenames"
+	ServeHTTP() error
+}
+
+type URLParser interface { // want "gostyle.ifacenames"
+	ParseURL() error
+}
@@ -1,9 +1,11 @@
 package mixedcaps
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/gostaticanalysis/testutil"
+	"github.com/k1LoW/gostyle/config"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -12,3 +14,18 @@ func TestAnalyzer(t *testing.T) {
 	td := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, td, Analyzer, "a")
 }
+
+// TestAnalyzerWithSuggestedFixes is a test for suggested fixes of Analyzer.
+func TestAnalyzerWithSuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a")
+}
+
+// TestAnalyzerWithConfigExcludes is a test for exclude-files and exclude-packages of AnalyzerWithConfig.
+func TestAnalyzerWithConfigExcludes(t *testing.T) {
+	td := testutil.WithModules(t, analysistest.TestData(), nil)
+	config.SetPath(filepath.Join(td, "src", "excludes", ".gostyle.yml"))
+	t.Cleanup(func() {
+		config.SetPath("")
+	})
+	analysistest.Run(t, td, AnalyzerWithConfig, "excludes/...")
+}
@@ -37,3 +37,13 @@ type i_a interface { //nostyle:all
 type S struct{}
 
 func (s_a *S) Foo() {} // want "gostyle.mixedcaps"
+
+func g() int {
+	total := 0
+	user_id := 1 // want "gostyle.mixedcaps"
+	func() {
+		userID := 2
+		total = user_id + userID
+	}()
+	return total
+}
@@ -0,0 +1,5 @@
+package excludes
+
+const MAX_LENGTH = 10 // want "gostyle.mixedcaps"
+
+const DEFAULT_LENGTH = 5
@@ -0,0 +1,3 @@
+module excludes
+
+go 1.21
@@ -0,0 +1,3 @@
+package excludes
+
+const MIN_LENGTH = 1
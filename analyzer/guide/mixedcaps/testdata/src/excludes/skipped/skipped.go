@@ -0,0 +1,3 @@
+package skipped
+
+const MAX_LENGTH = 10
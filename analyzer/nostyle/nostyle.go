@@ -0,0 +1,251 @@
+package nostyle
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gostaticanalysis/comment/passes/commentmap"
+	"github.com/k1LoW/gostyle/analyzer/code_review_comments/contexts"
+	"github.com/k1LoW/gostyle/analyzer/code_review_comments/dontpanic"
+	"github.com/k1LoW/gostyle/analyzer/code_review_comments/errorstrings"
+	"github.com/k1LoW/gostyle/analyzer/code_review_comments/handlerrors"
+	"github.com/k1LoW/gostyle/analyzer/code_review_comments/initialisms"
+	"github.com/k1LoW/gostyle/analyzer/decisions/funcfmt"
+	"github.com/k1LoW/gostyle/analyzer/decisions/getters"
+	"github.com/k1LoW/gostyle/analyzer/decisions/nilslices"
+	"github.com/k1LoW/gostyle/analyzer/decisions/pkgnames"
+	"github.com/k1LoW/gostyle/analyzer/decisions/recvnames"
+	"github.com/k1LoW/gostyle/analyzer/decisions/recvtype"
+	"github.com/k1LoW/gostyle/analyzer/decisions/repetition"
+	"github.com/k1LoW/gostyle/analyzer/decisions/underscores"
+	"github.com/k1LoW/gostyle/analyzer/decisions/useany"
+	"github.com/k1LoW/gostyle/analyzer/decisions/useq"
+	"github.com/k1LoW/gostyle/analyzer/decisions/varnames"
+	"github.com/k1LoW/gostyle/analyzer/effective/ifacenames"
+	"github.com/k1LoW/gostyle/analyzer/guide/mixedcaps"
+	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	name       = "nostyle"
+	doc        = "Analyzer that reports unknown and unused nostyle directives"
+	url        = "https://github.com/k1LoW/gostyle#nostyle"
+	msgUnknown = "The nostyle directive names an unknown analyzer. (THIS IS NOT IN Go Style)"
+	msgUnused  = "The nostyle directive suppresses nothing. Remove it so that suppressions do not pile up. (THIS IS NOT IN Go Style)"
+	msgReason  = "The nostyle directive requires the reason (e.g. `// reason` or `-- reason`). (THIS IS NOT IN Go Style)"
+)
+
+// Message kinds.
+const (
+	kindUnknown = "unknown"
+	kindUnused  = "unused"
+	kindReason  = "reason"
+)
+
+var (
+	disable             bool
+	includeGenerated    bool
+	excludeTest         bool
+	requireIgnoreReason bool
+)
+
+// analyzers is the list of analyzers that can be named in nostyle directives.
+var analyzers = []*analysis.Analyzer{
+	contexts.Analyzer,
+	dontpanic.Analyzer,
+	errorstrings.Analyzer,
+	funcfmt.Analyzer,
+	getters.Analyzer,
+	handlerrors.Analyzer,
+	ifacenames.Analyzer,
+	initialisms.Analyzer,
+	mixedcaps.Analyzer,
+	nilslices.Analyzer,
+	pkgnames.Analyzer,
+	recvnames.Analyzer,
+	recvtype.Analyzer,
+	repetition.Analyzer,
+	underscores.Analyzer,
+	useany.Analyzer,
+	useq.Analyzer,
+	varnames.Analyzer,
+}
+
+// analyzersWithConfig is the list of analyzers with config that can be named in nostyle directives.
+var analyzersWithConfig = []*analysis.Analyzer{
+	contexts.AnalyzerWithConfig,
+	dontpanic.AnalyzerWithConfig,
+	errorstrings.AnalyzerWithConfig,
+	funcfmt.AnalyzerWithConfig,
+	getters.AnalyzerWithConfig,
+	handlerrors.AnalyzerWithConfig,
+	ifacenames.AnalyzerWithConfig,
+	initialisms.AnalyzerWithConfig,
+	mixedcaps.AnalyzerWithConfig,
+	nilslices.AnalyzerWithConfig,
+	pkgnames.AnalyzerWithConfig,
+	recvnames.AnalyzerWithConfig,
+	recvtype.AnalyzerWithConfig,
+	repetition.AnalyzerWithConfig,
+	underscores.AnalyzerWithConfig,
+	useany.AnalyzerWithConfig,
+	useq.AnalyzerWithConfig,
+	varnames.AnalyzerWithConfig,
+}
+
+// Analyzer reports unknown and unused nostyle directives.
+// It runs after all analyzers that can be named in nostyle directives.
+var Analyzer = &analysis.Analyzer{
+	Name:     name,
+	Doc:      doc,
+	Run:      run,
+	Requires: append([]*analysis.Analyzer{commentmap.Analyzer}, analyzers...),
+}
+
+// AnalyzerWithConfig reports unknown and unused nostyle directives.
+// It runs after all analyzers that can be named in nostyle directives.
+var AnalyzerWithConfig = &analysis.Analyzer{
+	Name:     name,
+	Doc:      doc,
+	Run:      run,
+	Requires: append([]*analysis.Analyzer{config.Loader, commentmap.Analyzer}, analyzersWithConfig...),
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	flags := config.Nostyle{
+		Excludes:         config.Excludes{ExcludeTest: excludeTest},
+		IncludeGenerated: includeGenerated,
+	}
+	return nil, config.Each(pass, name, disable, flags, analyze)
+}
+
+func analyze(pass *analysis.Pass, s *config.Settings[config.Nostyle]) error {
+	requireIgnoreReason := requireIgnoreReason
+	if s.Config != nil {
+		requireIgnoreReason = s.Config.RequireIgnoreReason
+	}
+	// The reports are on the directives themselves, so they cannot be suppressed by the directives.
+	r, err := reporter.New(name, pass, append(s.Options, reporter.DisableNoStyle())...)
+	if err != nil {
+		return err
+	}
+	known := []string{reporter.IgnoreAll}
+	for _, a := range analyzers {
+		known = append(known, a.Name)
+	}
+
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, cm := range cg.List {
+				d, ok := reporter.ParseDirective(cm, reporter.NoStyleCommentAnnotation)
+				if !ok || d.Scope == reporter.ScopeEnd {
+					continue
+				}
+				// The directive without the reason suppresses nothing, so it is reported as invalid instead of unused.
+				invalid := requireIgnoreReason && !d.HasReason()
+				if invalid {
+					r.Append(cm.Pos(), fmt.Sprintf("%s: %s", msgReason, strings.Join(d.Analyzers, ",")), reporter.Kind(kindReason))
+				}
+				suppressed := reporter.Suppressed(cm)
+				var unknown, unused []string
+				for _, n := range d.Analyzers {
+					switch {
+					case !slices.Contains(known, n):
+						unknown = append(unknown, n)
+					case invalid:
+					case n == reporter.IgnoreAll:
+						if len(suppressed) == 0 {
+							unused = append(unused, n)
+						}
+					case reporter.Ran(f, n) && !slices.Contains(suppressed, n):
+						unused = append(unused, n)
+					}
+				}
+				if len(unknown) == 0 && len(unused) == 0 {
+					continue
+				}
+				fixes := removeFixes(pass, d, append(unknown, unused...))
+				for _, n := range unknown {
+					r.Append(cm.Pos(), fmt.Sprintf("%s: %s", msgUnknown, n), reporter.Kind(kindUnknown), reporter.Fixes(fixes...))
+				}
+				for _, n := range unused {
+					r.Append(cm.Pos(), fmt.Sprintf("%s: %s", msgUnused, n), reporter.Kind(kindUnused), reporter.Fixes(fixes...))
+				}
+			}
+		}
+	}
+	r.Report()
+	return nil
+}
+
+// removeFixes returns suggested fixes that remove the analyzer names from the directive.
+// If no analyzer names remain, the directive comment itself is removed.
+func removeFixes(pass *analysis.Pass, d *reporter.Directive, names []string) []analysis.SuggestedFix {
+	var remain []string
+	for _, n := range d.Analyzers {
+		if !slices.Contains(names, n) {
+			remain = append(remain, n)
+		}
+	}
+	if len(remain) > 0 {
+		return []analysis.SuggestedFix{
+			{
+				Message: fmt.Sprintf("Remove %s from the nostyle directive", strings.Join(names, ",")),
+				TextEdits: []analysis.TextEdit{
+					{Pos: d.Pos, End: d.End, NewText: []byte(strings.Join(remain, ","))},
+				},
+			},
+		}
+	}
+	if d.Scope == reporter.ScopeBegin {
+		// Removing the beginning of the range leaves the end of the range.
+		return nil
+	}
+	// Remove the comment only if it starts with the directive (e.g. not following a nolint directive).
+	t := strings.TrimPrefix(d.Comment.Text, "//")
+	if !strings.HasPrefix(strings.TrimSpace(t), reporter.NoStyleCommentAnnotation) {
+		return nil
+	}
+	tf := pass.Fset.File(d.Comment.Pos())
+	if tf == nil {
+		return nil
+	}
+	if pass.ReadFile == nil {
+		return nil
+	}
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return nil
+	}
+	start := tf.Offset(d.Comment.Pos())
+	end := tf.Offset(d.Comment.End())
+	for start > 0 && (src[start-1] == ' ' || src[start-1] == '\t') {
+		start--
+	}
+	if start == 0 || src[start-1] == '\n' {
+		// The comment is the only content of the line, so remove the line.
+		if end < len(src) && src[end] == '\n' {
+			end++
+		}
+	}
+	return []analysis.SuggestedFix{
+		{
+			Message: "Remove the nostyle directive",
+			TextEdits: []analysis.TextEdit{
+				{Pos: tf.Pos(start), End: tf.Pos(end)},
+			},
+		},
+	}
+}
+
+func init() {
+	meta.Register(meta.Meta{Name: name, Source: meta.SourceGostyle, URL: url, Kinds: []string{kindUnknown, kindUnused, kindReason}})
+	Analyzer.Flags.BoolVar(&disable, "disable", false, "disable "+name+" analyzer")
+	Analyzer.Flags.BoolVar(&includeGenerated, "include-generated", false, "include generated codes")
+	Analyzer.Flags.BoolVar(&excludeTest, "exclude-test", false, "exclude test files")
+	Analyzer.Flags.BoolVar(&requireIgnoreReason, "require-ignore-reason", false, "require the reason for nostyle directives")
+}
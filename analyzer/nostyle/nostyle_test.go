@@ -0,0 +1,29 @@
+package nostyle
+
+import (
+	"testing"
+
+	"github.com/gostaticanalysis/testutil"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer is a test for Analyzer.
+func TestAnalyzer(t *testing.T) {
+	td := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, td, Analyzer, "a")
+}
+
+// TestAnalyzerWithSuggestedFixes is a test for suggested fixes of Analyzer.
+func TestAnalyzerWithSuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a")
+}
+
+// TestAnalyzerWithRequireIgnoreReason is a test for Analyzer with -require-ignore-reason.
+func TestAnalyzerWithRequireIgnoreReason(t *testing.T) {
+	requireIgnoreReason = true
+	t.Cleanup(func() {
+		requireIgnoreReason = false
+	})
+	td := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, td, Analyzer, "b")
+}
@@ -0,0 +1,29 @@
+package a
+
+import "fmt"
+
+func f() {
+	var foo_bar = 1 //nostyle:underscores,mixedcaps
+	fmt.Println(foo_bar)
+
+	var fooBar = 2 //nostyle:underscores // want "gostyle.nostyle"
+	fmt.Println(fooBar)
+
+	var foo_baz = 3 //nostyle:underscorez,underscores,mixedcaps // want "gostyle.nostyle"
+	fmt.Println(foo_baz)
+
+	var fooQux = 4 //nostyle:all // want "gostyle.nostyle"
+	fmt.Println(fooQux)
+
+	var fooQuux = 5 //nostyle:underscores,mixedcaps // want "gostyle.nostyle" "gostyle.nostyle"
+	fmt.Println(fooQuux)
+
+	var foo_corge = 6 //nostyle:all
+	fmt.Println(foo_corge)
+
+	//nostyle:dontpanic // want "gostyle.nostyle"
+	fmt.Println("hello")
+
+	// comment //nostyle:foo // want "gostyle.nostyle"
+	fmt.Println("world")
+}
@@ -0,0 +1,5 @@
+//nostyle:file:underscores // want "gostyle.nostyle"
+
+package a
+
+var bA = 1
@@ -0,0 +1,17 @@
+package a
+
+//nostyle:begin underscores,mixedcaps // want "gostyle.nostyle" "gostyle.nostyle"
+var cA = 1
+
+//nostyle:end
+
+//nostyle:begin underscores,mixedcaps
+var c_b = 1
+
+//nostyle:end
+
+//nostyle:underscores,mixedcaps
+var c_c = 1
+
+//nostyle:file:mixedcaps
+var c_d = 1 //nostyle:underscores
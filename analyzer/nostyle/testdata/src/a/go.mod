@@ -0,0 +1,4 @@
+module a
+
+go 1.21
+
@@ -0,0 +1,12 @@
+package b
+
+var x_a = 1 /* want "gostyle.nostyle" */ //nostyle:underscores
+
+var x_b = 1 //nostyle:underscores // legacy API
+
+var x_c = 1 //nostyle:underscores -- legacy API
+
+//nostyle:begin underscores -- protocol constants
+var x_d = 1
+
+//nostyle:end
@@ -0,0 +1,4 @@
+module b
+
+go 1.21
+
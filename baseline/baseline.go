@@ -0,0 +1,221 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const version = 1
+
+// Baseline is a set of existing reports.
+// The reports are keyed by analyzer name, file, enclosing declaration and message fingerprint (not by line number),
+// so that the baseline survives unrelated edits.
+type Baseline struct {
+	dir      string
+	entries  map[key]*Entry
+	matched  map[key]map[string]struct{}
+	recorded map[key]*Entry
+	seen     map[string]struct{}
+	mu       sync.Mutex
+}
+
+// Entry is an entry of the baseline.
+type Entry struct {
+	Analyzer    string `json:"analyzer"`
+	File        string `json:"file"`
+	Decl        string `json:"decl"`
+	Fingerprint string `json:"fingerprint"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+type key struct {
+	analyzer    string
+	file        string
+	decl        string
+	fingerprint string
+}
+
+type file struct {
+	Version int      `json:"version"`
+	Entries []*Entry `json:"entries"`
+}
+
+// New returns a new empty Baseline. The file paths of entries are relative to dir.
+func New(dir string) *Baseline {
+	return &Baseline{
+		dir:      dir,
+		entries:  map[key]*Entry{},
+		matched:  map[key]map[string]struct{}{},
+		recorded: map[key]*Entry{},
+		seen:     map[string]struct{}{},
+	}
+}
+
+// Load loads the baseline file.
+func Load(p string) (*Baseline, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode baseline file: %w", err)
+	}
+	if f.Version != version {
+		return nil, fmt.Errorf("unsupported baseline file version: %d", f.Version)
+	}
+	bl := New(filepath.Dir(abs))
+	for _, e := range f.Entries {
+		k := e.key()
+		if ee, ok := bl.entries[k]; ok {
+			ee.Count += e.Count
+			continue
+		}
+		bl.entries[k] = e
+	}
+	return bl, nil
+}
+
+// Record records the report to be written as a new baseline.
+func (b *Baseline) Record(analyzer string, posn token.Position, decl, msg string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e := b.entry(analyzer, posn.Filename, decl, msg)
+	// The same file may be analyzed more than once (e.g. package and package with tests).
+	id := fmt.Sprintf("%s:%d:%d:%s:%s", posn.Filename, posn.Line, posn.Column, analyzer, e.Fingerprint)
+	if _, ok := b.seen[id]; ok {
+		return
+	}
+	b.seen[id] = struct{}{}
+	k := e.key()
+	if ee, ok := b.recorded[k]; ok {
+		ee.Count++
+		return
+	}
+	e.Count = 1
+	b.recorded[k] = e
+}
+
+// Match reports whether the report is in the baseline.
+// Each entry matches reports up to its count.
+func (b *Baseline) Match(analyzer string, posn token.Position, decl, msg string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	k := b.entry(analyzer, posn.Filename, decl, msg).key()
+	e, ok := b.entries[k]
+	if !ok {
+		return false
+	}
+	id := fmt.Sprintf("%s:%d:%d", posn.Filename, posn.Line, posn.Column)
+	m, ok := b.matched[k]
+	if !ok {
+		m = map[string]struct{}{}
+		b.matched[k] = m
+	}
+	if _, ok := m[id]; ok {
+		// already matched (e.g. package and package with tests)
+		return true
+	}
+	if len(m) >= e.Count {
+		return false
+	}
+	m[id] = struct{}{}
+	return true
+}
+
+// Stale returns the entries that did not match any report.
+// The count of each returned entry is the number of unmatched reports.
+func (b *Baseline) Stale() []*Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var stale []*Entry
+	for k, e := range b.entries {
+		n := e.Count - len(b.matched[k])
+		if n <= 0 {
+			continue
+		}
+		s := *e
+		s.Count = n
+		stale = append(stale, &s)
+	}
+	sortEntries(stale)
+	return stale
+}
+
+// Write writes the recorded reports as a baseline file.
+// It returns the number of written entries.
+func (b *Baseline) Write(p string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	f := file{Version: version, Entries: []*Entry{}}
+	for _, e := range b.recorded {
+		f.Entries = append(f.Entries, e)
+	}
+	sortEntries(f.Entries)
+	out, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(p, append(out, '\n'), 0o644); err != nil { //nolint:gosec
+		return 0, err
+	}
+	return len(f.Entries), nil
+}
+
+func (b *Baseline) entry(analyzer, filename, decl, msg string) *Entry {
+	if rel, err := filepath.Rel(b.dir, filename); err == nil {
+		filename = rel
+	}
+	return &Entry{
+		Analyzer:    analyzer,
+		File:        filepath.ToSlash(filename),
+		Decl:        decl,
+		Fingerprint: Fingerprint(msg),
+		Message:     msg,
+	}
+}
+
+func (e *Entry) key() key {
+	return key{
+		analyzer:    e.Analyzer,
+		file:        e.File,
+		decl:        e.Decl,
+		fingerprint: e.Fingerprint,
+	}
+}
+
+// Fingerprint returns the fingerprint of the normalized message.
+func Fingerprint(msg string) string {
+	n := strings.Join(strings.Fields(msg), " ")
+	s := sha256.Sum256([]byte(n))
+	return hex.EncodeToString(s[:])[:16]
+}
+
+func sortEntries(entries []*Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Decl != b.Decl {
+			return a.Decl < b.Decl
+		}
+		if a.Analyzer != b.Analyzer {
+			return a.Analyzer < b.Analyzer
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+}
@@ -0,0 +1,76 @@
+package baseline
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, ".gostyle-baseline.json")
+	posn := func(line int) token.Position {
+		return token.Position{Filename: filepath.Join(dir, "a.go"), Line: line, Column: 1}
+	}
+
+	w := New(dir)
+	w.Record("mixedcaps", posn(3), "const MAX_LENGTH", "message: MAX_LENGTH")
+	w.Record("mixedcaps", posn(3), "const MAX_LENGTH", "message: MAX_LENGTH") // same report
+	w.Record("recvtype", posn(7), "func (T).Foo", "message: Foo")
+	w.Record("recvtype", posn(8), "func (T).Foo", "message: Foo")
+	n, err := w.Write(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 2; n != want {
+		t.Errorf("got %v want %v", n, want)
+	}
+
+	b, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		analyzer string
+		posn     token.Position
+		decl     string
+		msg      string
+		want     bool
+	}{
+		{"mixedcaps", posn(10), "const MAX_LENGTH", "message:  MAX_LENGTH", true},
+		{"mixedcaps", posn(10), "const MAX_LENGTH", "message: MAX_LENGTH", true},
+		{"recvtype", posn(20), "func (T).Foo", "message: Foo", true},
+		{"recvtype", posn(21), "func (T).Foo", "message: Foo", true},
+		{"recvtype", posn(22), "func (T).Foo", "message: Foo", false},
+		{"recvtype", posn(23), "func (T).Bar", "message: Bar", false},
+	}
+	for _, tt := range tests {
+		if got := b.Match(tt.analyzer, tt.posn, tt.decl, tt.msg); got != tt.want {
+			t.Errorf("%s %s %s: got %v want %v", tt.analyzer, tt.posn, tt.decl, got, tt.want)
+		}
+	}
+	if got := b.Stale(); len(got) != 0 {
+		t.Errorf("got %v want no stale entries", got)
+	}
+}
+
+func TestStale(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, ".gostyle-baseline.json")
+	w := New(dir)
+	w.Record("mixedcaps", token.Position{Filename: filepath.Join(dir, "a.go"), Line: 3, Column: 1}, "const MAX_LENGTH", "message: MAX_LENGTH")
+	if _, err := w.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := b.Stale()
+	if len(got) != 1 {
+		t.Fatalf("got %v want %v", len(got), 1)
+	}
+	if want := "a.go"; got[0].File != want {
+		t.Errorf("got %v want %v", got[0].File, want)
+	}
+}
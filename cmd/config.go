@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/k1LoW/gostyle/analyzer"
+	"github.com/k1LoW/gostyle/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the config file",
+	Long:  `Manage the config file.`,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the config file",
+	Long: `Validate the config file and the nested config files in its directory tree.
+Unknown keys and invalid values are reported with their positions.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(configPath); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(os.Stderr, "config is valid")
+		return err
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Output the JSON Schema of the config file",
+	Long: `Output the JSON Schema of the config file.
+Editors can use it to complete and validate the config file (e.g. "# yaml-language-server: $schema=gostyle.schema.json").`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := config.Schema(analyzer.AnalyzersWithFlags)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(b))
+		return err
+	},
+}
+
+var configPrintPath string
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the resolved config",
+	Long: `Print the resolved config (with the default values, the presets, the extended configs and the nested config files merged) and the sources of the config.
+With --path, the config that applies to the file is printed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := config.Open(configPath)
+		if err != nil {
+			if !errors.Is(err, config.ErrNotFound) {
+				return err
+			}
+			c = config.Defaults()
+		}
+		if configPrintPath != "" {
+			p, err := filepath.Abs(configPrintPath)
+			if err != nil {
+				return err
+			}
+			c, err = c.ForFile(p)
+			if err != nil {
+				return err
+			}
+		}
+		return printConfig(os.Stdout, c)
+	},
+}
+
+func printConfig(w io.Writer, c *config.Config) error {
+	b, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "# Sources (merged in order):"); err != nil {
+		return err
+	}
+	if len(c.Sources()) == 0 {
+		if _, err := fmt.Fprintln(w, "#   (no config file, the default values)"); err != nil {
+			return err
+		}
+	}
+	for _, s := range c.Sources() {
+		if _, err := fmt.Fprintf(w, "#   %s\n", s); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configPrintCmd)
+	configPrintCmd.Flags().StringVarP(&configPath, "config", "c", "", "path of config file")
+	configPrintCmd.Flags().StringVarP(&configPrintPath, "path", "", "", "print the config that applies to the file")
+	configValidateCmd.Flags().StringVarP(&configPath, "config", "c", "", "path of config file")
+}
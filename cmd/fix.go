@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/k1LoW/gostyle/analyzer"
+	"github.com/k1LoW/gostyle/fixer"
+	"github.com/k1LoW/gostyle/runner"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	fixDiff  bool
+	fixWrite bool
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix [packages]",
+	Short: "Apply suggested fixes",
+	Long: `Apply suggested fixes of all analyzers.
+
+Without --write, no files are modified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := setConfigPath(); err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+		res, err := runner.Analyze(analyzer.Analyzers, args...)
+		if err != nil {
+			return err
+		}
+		var fixes []analysis.SuggestedFix
+		for _, d := range res.Diagnostics {
+			fixes = append(fixes, d.SuggestedFixes...)
+		}
+		edits, skipped := fixer.Resolve(res.Fset, fixes)
+		files := make([]string, 0, len(edits))
+		for f := range edits {
+			files = append(files, f)
+		}
+		sort.Strings(files)
+		for _, f := range files {
+			before, err := os.ReadFile(f)
+			if err != nil {
+				return err
+			}
+			after := fixer.Apply(before, edits[f])
+			switch {
+			case fixDiff:
+				if _, err := fmt.Fprint(os.Stdout, fixer.Diff(relPath(f), before, after)); err != nil {
+					return err
+				}
+			case !fixWrite:
+				if _, err := fmt.Fprintln(os.Stdout, relPath(f)); err != nil {
+					return err
+				}
+			}
+			if fixWrite {
+				fi, err := os.Stat(f)
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(f, after, fi.Mode()); err != nil {
+					return err
+				}
+			}
+		}
+		if skipped > 0 {
+			if _, err := fmt.Fprintf(os.Stderr, "%d conflicting fix(es) skipped. run again to apply them\n", skipped); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fixCmd)
+	fixCmd.Flags().StringVarP(&configPath, "config", "c", "", "path of config file")
+	fixCmd.Flags().BoolVarP(&fixDiff, "diff", "", false, "print unified diff of the fixes")
+	fixCmd.Flags().BoolVarP(&fixWrite, "write", "w", false, "write the fixes to the files")
+}
+
+func relPath(p string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return p
+	}
+	rel, err := filepath.Rel(wd, p)
+	if err != nil {
+		return p
+	}
+	return rel
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixRenamesUsesInTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module a\n\ngo 1.25\n",
+		"a.go":   "package a\n\nvar user_id = 1\n",
+		"a_test.go": `package a
+
+import "testing"
+
+func TestUser(t *testing.T) {
+	if user_id != 1 {
+		t.Fatal("unexpected")
+	}
+}
+`,
+	}
+	for n, s := range files {
+		if err := os.WriteFile(filepath.Join(dir, n), []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	t.Cleanup(func() {
+		configPath = ""
+		fixWrite = false
+	})
+	rootCmd.SetArgs([]string{"fix", "--write", "./..."})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"a.go": "package a\n\nvar userID = 1\n",
+		"a_test.go": `package a
+
+import "testing"
+
+func TestUser(t *testing.T) {
+	if userID != 1 {
+		t.Fatal("unexpected")
+	}
+}
+`,
+	}
+	for n, w := range want {
+		b, err := os.ReadFile(filepath.Join(dir, n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != w {
+			t.Errorf("%s: got\n%s\nwant\n%s", n, got, w)
+		}
+	}
+}
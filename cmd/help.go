@@ -8,7 +8,9 @@ import (
 	"unicode"
 
 	"github.com/k1LoW/gostyle/analyzer"
+	"github.com/k1LoW/gostyle/meta"
 	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/analysis"
 )
 
 const (
@@ -84,12 +86,22 @@ func helpCommand(c *cobra.Command, args []string) {
 			}
 			return analyzers[i].Name < analyzers[j].Name
 		})
-		for _, a := range analyzers {
-			if a.Name == rootCommandName {
+		// The analyzers are grouped by the style sources.
+		for _, src := range meta.Sources {
+			var group []*analysis.Analyzer
+			for _, a := range analyzers {
+				if a.Name != rootCommandName && meta.SourceOf(a.Name) == src {
+					group = append(group, a)
+				}
+			}
+			if len(group) == 0 {
 				continue
 			}
-			title := strings.Split(a.Doc, "\n\n")[0] //nostyle:varnames
-			fmt.Fprintf(w, "\n  %s %s", rpad(a.Name, padding+1), title)
+			fmt.Fprintf(w, "\n  %s (%s):", meta.Title(src), src)
+			for _, a := range group {
+				title := strings.Split(a.Doc, "\n\n")[0]
+				fmt.Fprintf(w, "\n    %s %s", rpad(a.Name, padding+1), title)
+			}
 		}
 	}
 	if c.HasHelpSubCommands() {
@@ -24,6 +24,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/k1LoW/gostyle/config"
 	"github.com/spf13/cobra"
@@ -32,7 +33,9 @@ import (
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Generate .gostyle.yml",
-	Long:  `Generate .gostyle.yml.`,
+	Long: `Generate .gostyle.yml.
+
+With --preset, the generated config uses the built-in preset (` + "`preset:`" + `).`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := generateConfig(); err != nil {
 			return err
@@ -41,16 +44,23 @@ var initCmd = &cobra.Command{
 	},
 }
 
+var initPreset string
+
 func init() {
 	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().StringVarP(&initPreset, "preset", "", "", fmt.Sprintf("built-in preset (%s)", strings.Join(config.Presets(), ", ")))
 }
 
 func generateConfig() error {
 	const name = ".gostyle.yml"
+	b, err := config.Init(initPreset)
+	if err != nil {
+		return err
+	}
 	if _, err := os.Stat(name); err == nil {
 		return fmt.Errorf("%s already exists", name)
 	}
-	if err := os.WriteFile(name, config.Default, os.ModePerm); err != nil { //nolint:gosec
+	if err := os.WriteFile(name, b, os.ModePerm); err != nil { //nolint:gosec
 		return err
 	}
 	if _, err := fmt.Fprintf(os.Stderr, "%s is generated\n", name); err != nil {
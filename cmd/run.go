@@ -22,35 +22,135 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/k1LoW/gostyle/analyzer"
+	"github.com/k1LoW/gostyle/baseline"
 	"github.com/k1LoW/gostyle/config"
+	"github.com/k1LoW/gostyle/formatter"
+	"github.com/k1LoW/gostyle/gitdiff"
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+	"github.com/k1LoW/gostyle/runner"
 	"github.com/spf13/cobra"
-	"golang.org/x/tools/go/analysis/multichecker"
+	"golang.org/x/tools/go/analysis"
 )
 
-var configPath string
+// exitCodeDiagnostics is the exit code when diagnostics are reported (same as multichecker).
+const exitCodeDiagnostics = 3
+
+// exitCodeError is the error to exit with the code.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+var (
+	configPath    string
+	format        string
+	failOn        string
+	baselinePath  string
+	writeBaseline string
+	newFromRev    string
+	newFromPatch  string
+	sources       []string
+)
 
 var runCmd = &cobra.Command{
 	Use:   "run [packages]",
 	Short: "Run analyzers",
 	Long:  `Run analyzers.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if configPath != "" && !filepath.IsAbs(configPath) {
-			abs, err := filepath.Abs(configPath)
-			if err != nil {
-				return err
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if !slices.Contains(formatter.Formats, format) {
+			return fmt.Errorf("unsupported format: %s", format)
+		}
+		if !slices.Contains(reporter.Severities, failOn) {
+			return fmt.Errorf("invalid severity: %s", failOn)
+		}
+		for _, src := range sources {
+			if !slices.Contains(meta.Sources, src) {
+				return fmt.Errorf("unknown source: %s (available: %s)", src, strings.Join(meta.Sources, ", "))
 			}
-			configPath = abs
 		}
-		config.SetPath(configPath)
+		if err := setConfigPath(); err != nil {
+			return err
+		}
+		analyzers := analyzer.Analyzers
+		if len(sources) > 0 {
+			analyzers = slices.DeleteFunc(slices.Clone(analyzers), func(a *analysis.Analyzer) bool {
+				return !slices.Contains(sources, meta.SourceOf(a.Name))
+			})
+		}
 		if len(args) == 0 {
 			args = []string{"."}
 		}
-		os.Args = append([]string{"gostlye"}, args...)
-		multichecker.Main(analyzer.Analyzers...)
+		var b *baseline.Baseline
+		switch {
+		case writeBaseline != "":
+			abs, err := filepath.Abs(writeBaseline)
+			if err != nil {
+				return err
+			}
+			b = baseline.New(filepath.Dir(abs))
+		case baselinePath != "":
+			b, err = baseline.Load(baselinePath)
+			if err != nil {
+				return err
+			}
+		}
+		reporter.SetBaseline(b)
+		var c *gitdiff.Changes
+		switch {
+		case newFromRev != "":
+			c, err = gitdiff.FromRev(newFromRev)
+			if err != nil {
+				return err
+			}
+		case newFromPatch != "":
+			c, err = gitdiff.FromPatch(newFromPatch)
+			if err != nil {
+				return err
+			}
+		}
+		reporter.SetChanges(c)
+		res, err := runner.Analyze(analyzers, args...)
+		if err != nil {
+			return err
+		}
+		if writeBaseline != "" {
+			n, err := b.Write(writeBaseline)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(os.Stderr, "%d baseline entries are written to %s\n", n, writeBaseline)
+			return err
+		}
+		w := cmd.OutOrStdout()
+		if format == formatter.Text {
+			w = cmd.ErrOrStderr()
+		}
+		if err := formatter.Write(w, format, analyzers, res.Diagnostics); err != nil {
+			return err
+		}
+		if b != nil {
+			if err := printStaleBaseline(b); err != nil {
+				return err
+			}
+		}
+		for _, d := range res.Diagnostics {
+			if slices.Index(reporter.Severities, d.Severity) >= slices.Index(reporter.Severities, failOn) {
+				// The diagnostics are already printed.
+				cmd.SilenceErrors = true
+				return &exitCodeError{code: exitCodeDiagnostics}
+			}
+		}
 		return nil
 	},
 }
@@ -58,4 +158,41 @@ var runCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(runCmd)
 	runCmd.Flags().StringVarP(&configPath, "config", "c", "", "path of config file")
+	runCmd.Flags().StringVarP(&failOn, "fail-on", "", reporter.SeverityInfo, fmt.Sprintf("minimum severity of diagnostics that makes the exit code non-zero (%s)", strings.Join(reporter.Severities, "|")))
+	runCmd.Flags().StringVarP(&format, "format", "f", formatter.Text, fmt.Sprintf("output format (%s)", strings.Join(formatter.Formats, "|")))
+	runCmd.Flags().StringVarP(&baselinePath, "baseline", "", "", "path of baseline file. reports in the baseline are not reported")
+	runCmd.Flags().StringVarP(&writeBaseline, "write-baseline", "", "", "write all reports to the baseline file")
+	runCmd.Flags().StringVarP(&newFromRev, "new-from-rev", "", "", "report only on the lines changed since the git revision")
+	runCmd.Flags().StringVarP(&newFromPatch, "new-from-patch", "", "", "report only on the lines changed in the patch file")
+	runCmd.Flags().StringSliceVarP(&sources, "source", "", nil, fmt.Sprintf("run only the analyzers of the style sources (%s)", strings.Join(meta.Sources, "|")))
+	runCmd.MarkFlagsMutuallyExclusive("baseline", "write-baseline")
+	runCmd.MarkFlagsMutuallyExclusive("new-from-rev", "new-from-patch")
+}
+
+func setConfigPath() error {
+	if configPath != "" && !filepath.IsAbs(configPath) {
+		abs, err := filepath.Abs(configPath)
+		if err != nil {
+			return err
+		}
+		configPath = abs
+	}
+	config.SetPath(configPath)
+	return nil
+}
+
+func printStaleBaseline(b *baseline.Baseline) error {
+	stale := b.Stale()
+	if len(stale) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(os.Stderr, "%d stale baseline entries can be pruned (regenerate the baseline with --write-baseline):\n", len(stale)); err != nil {
+		return err
+	}
+	for _, e := range stale {
+		if _, err := fmt.Fprintf(os.Stderr, "  %s: %s: [%s.%s] x%d\n", e.File, e.Decl, rootCommandName, e.Analyzer, e.Count); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunSeverityAndFailOn(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module a\n\ngo 1.25\n",
+		"a.go": `package a
+
+var s = []string{}
+
+func f(t []string) bool {
+	return t == nil
+}
+`,
+		".gostyle.yml": `analyzers:
+  default: none
+  enable:
+    - nilslices
+analyzers-settings:
+  nilslices:
+    kind-severity:
+      declaration: warning
+      comparison: info
+`,
+	}
+	for n, s := range files {
+		if err := os.WriteFile(filepath.Join(dir, n), []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	t.Cleanup(func() {
+		configPath = ""
+		format = "text"
+		failOn = "info"
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+
+	tests := []struct {
+		failOn  string
+		wantErr bool
+	}{
+		{"error", false},
+		{"warning", true},
+		{"info", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.failOn, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			rootCmd.SetOut(out)
+			rootCmd.SetErr(new(bytes.Buffer))
+			rootCmd.SetArgs([]string{"run", "-c", filepath.Join(dir, ".gostyle.yml"), "--format", "json", "--fail-on", tt.failOn, "./..."})
+			err := rootCmd.Execute()
+			var ec *exitCodeError
+			if got := errors.As(err, &ec) && ec.code == exitCodeDiagnostics; got != tt.wantErr {
+				t.Errorf("got %v want exit code error %v", err, tt.wantErr)
+			}
+
+			var diags []struct {
+				Analyzer string `json:"analyzer"`
+				Line     int    `json:"line"`
+				Severity string `json:"severity"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &diags); err != nil {
+				t.Fatal(err)
+			}
+			want := map[int]string{
+				3: "warning", // declaration
+				6: "info",    // comparison
+			}
+			if len(diags) != len(want) {
+				t.Fatalf("got %v want %v", diags, want)
+			}
+			for _, d := range diags {
+				if d.Analyzer != "nilslices" || d.Severity != want[d.Line] {
+					t.Errorf("got %v want %s at line %d", d, want[d.Line], d.Line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © 2025 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k1LoW/gostyle/reporter"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	suppressionsFormatTable = "table"
+	suppressionsFormatJSON  = "json"
+)
+
+var suppressionsFormat string
+
+// suppression is a nostyle directive.
+type suppression struct {
+	File      string   `json:"file"`
+	Line      int      `json:"line"`
+	Analyzers []string `json:"analyzers"`
+	Scope     string   `json:"scope"`
+	Reason    string   `json:"reason"`
+}
+
+var suppressionsCmd = &cobra.Command{
+	Use:   "suppressions [packages]",
+	Short: "List nostyle directives",
+	Long:  `List nostyle directives (suppressions) with the analyzers, the scope and the reason.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if suppressionsFormat != suppressionsFormatTable && suppressionsFormat != suppressionsFormatJSON {
+			return fmt.Errorf("unsupported format: %s", suppressionsFormat)
+		}
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+		s, err := collectSuppressions(args...)
+		if err != nil {
+			return err
+		}
+		if suppressionsFormat == suppressionsFormatJSON {
+			return writeSuppressionsJSON(os.Stdout, s)
+		}
+		return writeSuppressionsTable(os.Stdout, s)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(suppressionsCmd)
+	suppressionsCmd.Flags().StringVarP(&suppressionsFormat, "format", "f", suppressionsFormatTable, fmt.Sprintf("output format (%s|%s)", suppressionsFormatTable, suppressionsFormatJSON))
+}
+
+// collectSuppressions returns the nostyle directives in the packages (including tests).
+func collectSuppressions(patterns ...string) ([]*suppression, error) {
+	conf := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(conf, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("failed to load packages: %d error(s)", n)
+	}
+	var s []*suppression
+	seen := map[string]struct{}{}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Syntax {
+			// The same file may be loaded more than once (e.g. package and package with tests).
+			name := pkg.Fset.File(f.Pos()).Name()
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			for _, cg := range f.Comments {
+				for _, c := range cg.List {
+					d, ok := reporter.ParseDirective(c, reporter.NoStyleCommentAnnotation)
+					if !ok || d.Scope == reporter.ScopeEnd {
+						continue
+					}
+					s = append(s, &suppression{
+						File:      relPath(name),
+						Line:      pkg.Fset.Position(c.Pos()).Line,
+						Analyzers: d.Analyzers,
+						Scope:     d.Scope,
+						Reason:    d.Reason,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].File != s[j].File {
+			return s[i].File < s[j].File
+		}
+		return s[i].Line < s[j].Line
+	})
+	return s, nil
+}
+
+func writeSuppressionsTable(w io.Writer, s []*suppression) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "FILE\tANALYZERS\tSCOPE\tREASON"); err != nil {
+		return err
+	}
+	for _, ss := range s {
+		if _, err := fmt.Fprintf(tw, "%s:%d\t%s\t%s\t%s\n", ss.File, ss.Line, strings.Join(ss.Analyzers, ","), ss.Scope, ss.Reason); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func writeSuppressionsJSON(w io.Writer, s []*suppression) error {
+	if len(s) == 0 {
+		s = []*suppression{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(s)
+}
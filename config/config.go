@@ -1,15 +1,40 @@
 package config
 
 import (
+	"bytes"
 	_ "embed"
+	"fmt"
+	"reflect"
 	"slices"
 
+	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+
 	"golang.org/x/tools/go/analysis"
 )
 
 //go:embed .gostyle.yml.init
 var Default []byte
 
+// Init returns the content of the config file generated by `gostyle init`.
+// If the preset is specified, the config uses the preset and the settings are commented out.
+func Init(name string) ([]byte, error) {
+	if name == "" {
+		return Default, nil
+	}
+	if _, err := preset(name); err != nil {
+		return nil, err
+	}
+	b := fmt.Appendf(nil, "preset: %s # the settings below are merged over the preset.\n", name)
+	b = append(b, "# analyzers:\n#   disable:\n#     - analyzer-name\n"...)
+	if i := bytes.Index(Default, []byte("# analyzers-settings:")); i >= 0 {
+		b = append(b, Default[i:]...)
+	}
+	return b, nil
+}
+
 const (
 	DefaultSmallScopeMax       = 7
 	DefaultSmallVarnameMax     = -1
@@ -22,16 +47,55 @@ const (
 )
 
 type Config struct {
-	Analyzers         Analyzers         `yaml:"analyzers"`
-	AnalyzersSettings AnalyzersSettings `yaml:"analyzers-settings"`
-	ExcludeFiles      []string          `yaml:"exclude-files"`
-	ConfigDir         string            `yaml:"-"`
-	loaded            bool
-	err               error
+	Preset              string            `yaml:"preset,omitempty" desc:"built-in preset used as the base config"`
+	Extends             string            `yaml:"extends,omitempty" desc:"base config (a path relative to the config file or a preset name)"`
+	Analyzers           Analyzers         `yaml:"analyzers" desc:"analyzers to disable or enable"`
+	AnalyzersSettings   AnalyzersSettings `yaml:"analyzers-settings" desc:"settings of analyzers"`
+	ExcludeFiles        []string          `yaml:"exclude-files" desc:"files to exclude from analysis (globbing relative to the config file)"`
+	RequireIgnoreReason bool              `yaml:"require-ignore-reason"`
+	// Tests is whether test files are analyzed (nil means true).
+	Tests       *bool            `yaml:"tests,omitempty" desc:"analyze test files (false: all analyzers exclude test files)" default:"true"`
+	Initialisms InitialismsTable `yaml:"initialisms,omitempty" desc:"initialisms added to or removed from the default initialisms (used by the analyzers checking names)"`
+	Overrides   []Override       `yaml:"overrides,omitempty" desc:"settings for specific paths"`
+	ConfigDir   string           `yaml:"-"`
+	loaded      bool
+	err         error
+	overridden  *overridden
+	nested      *nested
+	// sources is the list of config files (and presets) merged into the config in order.
+	sources []string
 }
 
+// InitialismsTable is the initialisms added to or removed from the default initialisms table.
+type InitialismsTable struct {
+	Add    []string `yaml:"add,omitempty" desc:"initialisms to add (e.g. GRPC, K8S)"`
+	Remove []string `yaml:"remove,omitempty" desc:"initialisms to remove from the default initialisms"`
+}
+
+// Table returns the initialisms table.
+func (i *InitialismsTable) Table() *detector.Initialisms {
+	if len(i.Add) == 0 && len(i.Remove) == 0 {
+		return detector.DefaultInitialisms
+	}
+	return detector.NewInitialisms(i.Add, i.Remove)
+}
+
+// Values of Analyzers.Default.
+const (
+	// AnalyzersDefaultAll enables all analyzers except the disabled analyzers.
+	AnalyzersDefaultAll = "all"
+	// AnalyzersDefaultNone enables only the enabled analyzers.
+	AnalyzersDefaultNone = "none"
+)
+
 type Analyzers struct {
-	Disable []string `yaml:"disable"`
+	// Default is whether analyzers are enabled unless listed (AnalyzersDefaultAll or AnalyzersDefaultNone).
+	Default string   `yaml:"default,omitempty" desc:"analyzers enabled by default (all: all analyzers except the disabled analyzers, none: only the enabled analyzers)" default:"all"`
+	Disable []string `yaml:"disable" desc:"analyzers to disable"`
+	// Enable is the list of analyzers to enable (with AnalyzersDefaultNone, or that are disabled by the base config).
+	Enable []string `yaml:"enable" desc:"analyzers to enable"`
+	// EnableSources is the list of style sources (meta.Sources) whose analyzers are enabled. The other analyzers are disabled unless listed in Enable.
+	EnableSources []string `yaml:"enable-sources,omitempty" desc:"style sources whose analyzers are enabled (the analyzers of the other sources are disabled unless enabled)"`
 }
 
 type AnalyzersSettings struct {
@@ -42,8 +106,10 @@ type AnalyzersSettings struct {
 	Getters      Getters      `yaml:"getters"`
 	Handlerrors  Handlerrors  `yaml:"handlerrors"`
 	Ifacenames   Ifacenames   `yaml:"ifacenames"`
+	Initialisms  Initialisms  `yaml:"initialisms"`
 	Mixedcaps    Mixedcaps    `yaml:"mixedcaps"`
 	Nilslices    Nilslices    `yaml:"nilslices"`
+	Nostyle      Nostyle      `yaml:"nostyle"`
 	Pkgnames     Pkgnames     `yaml:"pkgnames"`
 	Recvnames    Recvnames    `yaml:"recvnames"`
 	Recvtype     Recvtype     `yaml:"recvtype"`
@@ -55,87 +121,141 @@ type AnalyzersSettings struct {
 	Varnames     Varnames     `yaml:"varnames"`
 }
 
+// Excludes is the files and packages excluded from the analyzer (in addition to exclude-files of the config).
+type Excludes struct {
+	// ExcludeFiles is the list of the glob patterns of files. The relative paths are resolved relative to the config file that defines them.
+	ExcludeFiles    []string `yaml:"exclude-files,omitempty" desc:"exclude files from the analyzer"`
+	ExcludePackages []string `yaml:"exclude-packages,omitempty" desc:"exclude packages from the analyzer by the glob patterns of import paths (e.g. */internal/testutil)"`
+	ExcludeTest     bool     `yaml:"exclude-test"`
+}
+
 type Contexts struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
-	ExcludeTest      bool `yaml:"exclude-test"`
 }
 
 type Dontpanic struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
-	ExcludeTest      bool `yaml:"exclude-test"`
 }
 
 type Errorstrings struct {
-	IncludeGenerated bool `yaml:"include-generated"`
-	ExcludeTest      bool `yaml:"exclude-test"`
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
+	IncludeGenerated bool     `yaml:"include-generated"`
+	Constructors     []string `yaml:"constructors"`
 }
 
 type Funcfmt struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 	CheckCalls       bool `yaml:"check-calls"`
 }
 
 type Getters struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	Exclude          []string `yaml:"exclude"`
 	IncludeGenerated bool     `yaml:"include-generated"`
 }
 
 type Handlerrors struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
-	ExcludeTest      bool `yaml:"exclude-test"`
 }
 
 type Ifacenames struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	All              bool `yaml:"all"`
 	IncludeGenerated bool `yaml:"include-generated"`
 }
 
+type Initialisms struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
+	Exclude          []string `yaml:"exclude"`
+	IncludeGenerated bool     `yaml:"include-generated"`
+}
+
 type Mixedcaps struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	Exclude          []string `yaml:"exclude"`
 	IncludeGenerated bool     `yaml:"include-generated"`
 }
 
 type Nilslices struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
+	IncludeGenerated bool `yaml:"include-generated"`
+}
+
+type Nostyle struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 }
 
 type Pkgnames struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 }
 
 type Recvnames struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 	Max              int  `yaml:"max"`
 }
 
 type Recvtype struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 }
 
 type Repetition struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	Exclude          []string `yaml:"exclude"`
 	IncludeGenerated bool     `yaml:"include-generated"`
 }
 
 type Typealiases struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	Exclude          []string `yaml:"exclude"`
 	IncludeGenerated bool     `yaml:"include-generated"`
 }
 
 type Underscores struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	Exclude          []string `yaml:"exclude"`
 	IncludeGenerated bool     `yaml:"include-generated"`
 }
 
 type Useany struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 }
 
 type Useq struct {
+	Severities       `yaml:",inline"`
+	Excludes         `yaml:",inline"`
 	IncludeGenerated bool `yaml:"include-generated"`
 }
 
 type Varnames struct {
+	Severities          `yaml:",inline"`
+	Excludes            `yaml:",inline"`
 	Exclude             []string `yaml:"exclude"`
 	IncludeGenerated    bool     `yaml:"include-generated"`
 	SmallScopeMax       int      `yaml:"small-scope-max"`
@@ -147,8 +267,91 @@ type Varnames struct {
 	VeryLargeVarnameMax int      `yaml:"very-large-varname-max"`
 }
 
+// Severities is the severity settings of an analyzer.
+type Severities struct {
+	Severity     string            `yaml:"severity" desc:"severity of all reports of the analyzer" default:"error"`
+	KindSeverity map[string]string `yaml:"kind-severity" desc:"severity per message kind"`
+}
+
+// Sources returns the config files (and presets and overrides) merged into the config in order.
+func (c *Config) Sources() []string {
+	return c.sources
+}
+
+// Marshal returns the resolved config as YAML.
+func (c *Config) Marshal() ([]byte, error) {
+	rc := *c
+	// The preset and the extended config are already merged.
+	rc.Preset = ""
+	rc.Extends = ""
+	return yaml.Marshal(&rc)
+}
+
+// AnalyzeTests reports whether test files are analyzed.
+func (c *Config) AnalyzeTests() bool {
+	return c.Tests == nil || *c.Tests
+}
+
+// ReporterOptions returns the options of the reporter of the analyzer.
+// They are built from exclude-files, require-ignore-reason and tests of the config,
+// and the severities, the excludes and include-generated in the analyzers-settings of the analyzer.
+func (c *Config) ReporterOptions(name string) []reporter.Option {
+	opts := []reporter.Option{
+		reporter.ExcludeFiles(c.ConfigDir, c.ExcludeFiles),
+		reporter.RequireIgnoreReason(c.RequireIgnoreReason),
+	}
+	var includeGenerated, excludeTest bool
+	if s, ok := c.settingsOf(name); ok {
+		if ss, ok := fieldOf[Severities](s, "Severities"); ok {
+			opts = append(opts, reporter.Severity(ss.Severity, ss.KindSeverity))
+		}
+		if ex, ok := fieldOf[Excludes](s, "Excludes"); ok {
+			opts = append(opts, reporter.ExcludeFiles(c.ConfigDir, ex.ExcludeFiles))
+			opts = append(opts, reporter.ExcludePackages(ex.ExcludePackages))
+			excludeTest = ex.ExcludeTest
+		}
+		includeGenerated, _ = fieldOf[bool](s, "IncludeGenerated")
+	}
+	return append(opts, reporter.FileOptions(includeGenerated, excludeTest || !c.AnalyzeTests())...)
+}
+
+// settingsOf returns the analyzers-settings of the analyzer.
+func (c *Config) settingsOf(name string) (reflect.Value, bool) {
+	rv := reflect.ValueOf(&c.AnalyzersSettings).Elem()
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		if yamlName(rt.Field(i)) == name {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// fieldOf returns the field of the struct value.
+func fieldOf[T any](v reflect.Value, name string) (T, bool) {
+	var zero T
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		return zero, false
+	}
+	val, ok := f.Interface().(T)
+	if !ok {
+		return zero, false
+	}
+	return val, true
+}
+
 func (c *Config) IsDisabled(name string) bool {
-	return slices.Contains(c.Analyzers.Disable, name)
+	if slices.Contains(c.Analyzers.Disable, name) {
+		return true
+	}
+	if slices.Contains(c.Analyzers.Enable, name) {
+		return false
+	}
+	if len(c.Analyzers.EnableSources) > 0 {
+		return !slices.Contains(c.Analyzers.EnableSources, meta.SourceOf(name))
+	}
+	return c.Analyzers.Default == AnalyzersDefaultNone && !slices.Contains(c.Analyzers.Enable, name)
 }
 
 func Load(pass *analysis.Pass) (*Config, error) {
@@ -1 +1,31 @@
 package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSettingsOf(t *testing.T) {
+	c := &Config{}
+	rt := reflect.TypeOf(c.AnalyzersSettings)
+	for i := range rt.NumField() {
+		name := yamlName(rt.Field(i))
+		s, ok := c.settingsOf(name)
+		if !ok {
+			t.Errorf("%s: settings not found", name)
+			continue
+		}
+		if _, ok := fieldOf[Severities](s, "Severities"); !ok {
+			t.Errorf("%s: no severities", name)
+		}
+		if _, ok := fieldOf[Excludes](s, "Excludes"); !ok {
+			t.Errorf("%s: no excludes", name)
+		}
+		if _, ok := fieldOf[bool](s, "IncludeGenerated"); !ok {
+			t.Errorf("%s: no include-generated", name)
+		}
+	}
+	if _, ok := c.settingsOf("unknown"); ok {
+		t.Error("want not found")
+	}
+}
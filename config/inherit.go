@@ -0,0 +1,234 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-yaml"
+)
+
+//go:embed presets/*.yml
+var presetFS embed.FS
+
+// Presets returns the names of the built-in presets.
+func Presets() []string {
+	names := []string{"default"}
+	entries, err := presetFS.ReadDir("presets")
+	if err != nil {
+		return names
+	}
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yml"))
+	}
+	return names
+}
+
+// presetName returns the name of the preset as the source of the config.
+func presetName(name string) string {
+	return "preset:" + name
+}
+
+// preset returns the config of the built-in preset (`default` is the config generated by `gostyle init`).
+func preset(name string) ([]byte, error) {
+	if name == "default" {
+		return Default, nil
+	}
+	b, err := presetFS.ReadFile(path.Join("presets", name+".yml"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset: %s (available: %s)", name, strings.Join(Presets(), ", "))
+	}
+	return b, nil
+}
+
+// nested is the cache of the configs for the directories under the directory of the root config, keyed by the directories.
+type nested struct {
+	configs map[string]*Config
+	mu      sync.Mutex
+}
+
+// load reads the config file and merges it over the parent config.
+// seen is the list of config files being loaded to detect circular extends.
+func load(p string, parent *Config, seen []string) (*Config, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	return parse(b, p, filepath.Dir(p), parent, seen)
+}
+
+// parse parses the config (named name) and merges it over the parent config (and the preset and the config it extends, in that order).
+// The relative paths in the config are resolved relative to dir.
+func parse(b []byte, name, dir string, parent *Config, seen []string) (*Config, error) {
+	own := &Config{}
+	if err := validate(name, b, own); err != nil {
+		return nil, err
+	}
+	base := parent
+	if own.Preset != "" {
+		b, err := preset(own.Preset)
+		if err != nil {
+			return nil, err
+		}
+		base, err = parse(b, presetName(own.Preset), dir, base, seen)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if own.Extends != "" {
+		var err error
+		base, err = extend(own.Extends, dir, base, seen)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return base.merge(b, own, name, dir)
+}
+
+// extend returns the config named by `extends:` merged over the parent config.
+// The name is a path to the config file (relative to dir) or a preset name.
+func extend(name, dir string, parent *Config, seen []string) (*Config, error) {
+	if !strings.ContainsAny(name, `/\`) && filepath.Ext(name) == "" {
+		b, err := preset(name)
+		if err != nil {
+			return nil, err
+		}
+		return parse(b, presetName(name), dir, parent, seen)
+	}
+	p := name
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(dir, p)
+	}
+	if slices.Contains(seen, p) {
+		return nil, fmt.Errorf("circular extends: %s", strings.Join(append(seen, p), " -> "))
+	}
+	return load(p, parent, append(slices.Clone(seen), p))
+}
+
+// merge returns a copy of the config with the config b (decoded as own, named name) merged over it.
+// The keys specified in b replace the values of the config, except that
+// the disabled and enabled analyzers are merged by mergeAnalyzers, and exclude-files and overrides are appended to those of the config.
+func (c *Config) merge(b []byte, own *Config, name, dir string) (*Config, error) {
+	mc := *c
+	mc.err = nil
+	mc.loaded = false
+	mc.overridden = nil
+	mc.nested = nil
+	if err := yaml.Unmarshal(b, &mc); err != nil {
+		return nil, fmt.Errorf("failed to decode config file: %w", err)
+	}
+	mc.ConfigDir = dir
+	mc.sources = append(slices.Clone(c.sources), name)
+	mc.AnalyzersSettings.resolve(dir)
+
+	mc.Analyzers = mergeAnalyzers(c.Analyzers, own.Analyzers)
+
+	// The paths are made absolute because they are relative to the config files that define them.
+	mc.ExcludeFiles = slices.Clone(c.ExcludeFiles)
+	for _, f := range own.ExcludeFiles {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(dir, f)
+		}
+		mc.ExcludeFiles = append(mc.ExcludeFiles, f)
+	}
+	mc.Overrides = slices.Clone(c.Overrides)
+	for _, o := range own.Overrides {
+		o.dir = dir
+		o.source = name
+		mc.Overrides = append(mc.Overrides, o)
+	}
+	return &mc, nil
+}
+
+// resolve makes the relative paths of exclude-files of the analyzers absolute (relative to dir).
+// The paths already resolved (e.g. inherited from the base config) are kept.
+func (s *AnalyzersSettings) resolve(dir string) {
+	rv := reflect.ValueOf(s).Elem()
+	for i := range rv.NumField() {
+		fv := rv.Field(i).FieldByName("ExcludeFiles")
+		if !fv.IsValid() {
+			continue
+		}
+		files := slices.Clone(fv.Interface().([]string))
+		for j, f := range files {
+			if !filepath.IsAbs(f) {
+				files[j] = filepath.Join(dir, f)
+			}
+		}
+		fv.Set(reflect.ValueOf(files))
+	}
+}
+
+// mergeAnalyzers returns the analyzers with o merged over base.
+// The disabled (enabled) analyzers of o are appended to those of base and removed from the enabled (disabled) analyzers.
+// The default and the enabled sources of o replace those of base.
+func mergeAnalyzers(base, o Analyzers) Analyzers {
+	m := Analyzers{Default: base.Default, EnableSources: base.EnableSources}
+	if o.Default != "" {
+		m.Default = o.Default
+	}
+	if len(o.EnableSources) > 0 {
+		m.EnableSources = o.EnableSources
+	}
+	for _, n := range append(slices.Clone(base.Disable), o.Disable...) {
+		if !slices.Contains(m.Disable, n) && !slices.Contains(o.Enable, n) {
+			m.Disable = append(m.Disable, n)
+		}
+	}
+	for _, n := range append(slices.Clone(base.Enable), o.Enable...) {
+		if !slices.Contains(m.Enable, n) && !slices.Contains(o.Disable, n) {
+			m.Enable = append(m.Enable, n)
+		}
+	}
+	return m
+}
+
+// nearest returns the config for the directory.
+// The config file in the directory or its nearest parent directory under the directory of the root config
+// is merged over the config of its parent directory.
+func (c *Config) nearest(dir string) *Config {
+	if c.nested == nil || !strings.HasPrefix(dir, c.ConfigDir+string(filepath.Separator)) {
+		return c
+	}
+	c.nested.mu.Lock()
+	nc, ok := c.nested.configs[dir]
+	c.nested.mu.Unlock()
+	if ok {
+		return nc
+	}
+	nc = c.nearest(filepath.Dir(dir))
+	if nc.err == nil {
+		for _, n := range defaultFileNames {
+			p := filepath.Join(dir, n)
+			if _, err := os.Stat(p); err != nil {
+				continue
+			}
+			nc = nestedConfig(p, nc)
+			break
+		}
+	}
+	c.nested.mu.Lock()
+	defer c.nested.mu.Unlock()
+	if prev, ok := c.nested.configs[dir]; ok {
+		return prev
+	}
+	c.nested.configs[dir] = nc
+	return nc
+}
+
+func nestedConfig(p string, parent *Config) *Config {
+	nc, err := load(p, parent, []string{p})
+	if err != nil {
+		return &Config{err: err}
+	}
+	if err := nc.prepare(); err != nil {
+		return &Config{err: err}
+	}
+	return nc
+}
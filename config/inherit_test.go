@@ -0,0 +1,370 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/k1LoW/gostyle/meta"
+)
+
+func TestNestedAndExtends(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".gostyle.yml": `
+analyzers:
+  disable:
+    - mixedcaps
+analyzers-settings:
+  varnames:
+    exclude:
+      - a
+    small-varname-max: 4
+  dontpanic:
+    exclude-files:
+      - cmd/**/main.go
+exclude-files:
+  - gen/*.go
+`,
+		"base.yml": `
+analyzers:
+  disable:
+    - dontpanic
+analyzers-settings:
+  recvnames:
+    max: 3
+`,
+		"sub/.gostyle.yml": `
+extends: ../base.yml
+analyzers:
+  enable:
+    - mixedcaps
+analyzers-settings:
+  varnames:
+    exclude:
+      - b
+exclude-files:
+  - x.go
+overrides:
+  - paths:
+      - internal
+    analyzers:
+      disable:
+        - useq
+    analyzers-settings:
+      dontpanic:
+        exclude-files:
+          - main.go
+`,
+		"preset/.gostyle.yml": `
+extends: default
+analyzers:
+  disable:
+    - useq
+`,
+		"cycle/.gostyle.yml": `
+extends: ./a.yml
+`,
+		"cycle/a.yml": `
+extends: ./.gostyle.yml
+`,
+	}
+	for n, b := range files {
+		p := filepath.Join(dir, n)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(b), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := loadRoot(filepath.Join(dir, ".gostyle.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file         string
+		wantDisable  []string
+		wantExclude  []string
+		wantFiles    []string
+		wantRecvMax  int
+		wantSmallMax int
+		wantPanic    []string
+	}{
+		{"a.go", []string{"mixedcaps"}, []string{"a"}, []string{"gen/*.go"}, 2, 4, []string{"cmd/**/main.go"}},
+		{"other/a.go", []string{"mixedcaps"}, []string{"a"}, []string{"gen/*.go"}, 2, 4, []string{"cmd/**/main.go"}},
+		{"sub/a.go", []string{"dontpanic"}, []string{"b"}, []string{"gen/*.go", "sub/x.go"}, 3, 4, []string{"cmd/**/main.go"}},
+		{"sub/deep/a.go", []string{"dontpanic"}, []string{"b"}, []string{"gen/*.go", "sub/x.go"}, 3, 4, []string{"cmd/**/main.go"}},
+		{"sub/internal/a.go", []string{"dontpanic", "useq"}, []string{"b"}, []string{"gen/*.go", "sub/x.go"}, 3, 4, []string{"sub/main.go"}},
+		{"preset/a.go", []string{"mixedcaps", "useq"}, []string{"a"}, []string{"gen/*.go"}, 2, 4, []string{"cmd/**/main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			ec := c.For(filepath.Join(dir, tt.file))
+			if ec.err != nil {
+				t.Fatal(ec.err)
+			}
+			if !slices.Equal(ec.Analyzers.Disable, tt.wantDisable) {
+				t.Errorf("got %v want %v", ec.Analyzers.Disable, tt.wantDisable)
+			}
+			if !slices.Equal(ec.AnalyzersSettings.Varnames.Exclude, tt.wantExclude) {
+				t.Errorf("got %v want %v", ec.AnalyzersSettings.Varnames.Exclude, tt.wantExclude)
+			}
+			var wantFiles []string
+			for _, f := range tt.wantFiles {
+				wantFiles = append(wantFiles, filepath.Join(dir, f))
+			}
+			if !slices.Equal(ec.ExcludeFiles, wantFiles) {
+				t.Errorf("got %v want %v", ec.ExcludeFiles, wantFiles)
+			}
+			var wantPanic []string
+			for _, f := range tt.wantPanic {
+				wantPanic = append(wantPanic, filepath.Join(dir, f))
+			}
+			if !slices.Equal(ec.AnalyzersSettings.Dontpanic.ExcludeFiles, wantPanic) {
+				t.Errorf("got %v want %v", ec.AnalyzersSettings.Dontpanic.ExcludeFiles, wantPanic)
+			}
+			if got := ec.AnalyzersSettings.Recvnames.Max; got != tt.wantRecvMax {
+				t.Errorf("got %v want %v", got, tt.wantRecvMax)
+			}
+			if got := ec.AnalyzersSettings.Varnames.SmallVarnameMax; got != tt.wantSmallMax {
+				t.Errorf("got %v want %v", got, tt.wantSmallMax)
+			}
+		})
+	}
+
+	t.Run("sources", func(t *testing.T) {
+		ec := c.For(filepath.Join(dir, "sub", "internal", "a.go"))
+		want := []string{
+			filepath.Join(dir, ".gostyle.yml"),
+			filepath.Join(dir, "base.yml"),
+			filepath.Join(dir, "sub", ".gostyle.yml"),
+			filepath.Join(dir, "sub", ".gostyle.yml") + ": overrides (paths: internal)",
+		}
+		if got := ec.Sources(); !slices.Equal(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+		b, err := ec.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The resolved config is a valid config.
+		rc := &Config{}
+		if err := validate("resolved", b, rc); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(rc.Analyzers.Disable, ec.Analyzers.Disable) {
+			t.Errorf("got %v want %v", rc.Analyzers.Disable, ec.Analyzers.Disable)
+		}
+	})
+
+	t.Run("circular extends", func(t *testing.T) {
+		ec := c.For(filepath.Join(dir, "cycle", "a.go"))
+		if ec.err == nil || !strings.Contains(ec.err.Error(), "circular extends") {
+			t.Errorf("got %v want circular extends error", ec.err)
+		}
+	})
+}
+
+func TestPresets(t *testing.T) {
+	for _, n := range Presets() {
+		t.Run(n, func(t *testing.T) {
+			if _, err := parse([]byte("preset: "+n), ".gostyle.yml", t.TempDir(), &Config{}, nil); err != nil {
+				t.Error(err)
+			}
+			b, err := Init(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := parse(b, ".gostyle.yml", t.TempDir(), &Config{}, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	c, err := parse([]byte(`
+preset: google-strict
+analyzers:
+  disable:
+    - dontpanic
+analyzers-settings:
+  varnames:
+    small-varname-max: 8
+`), ".gostyle.yml", t.TempDir(), &Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"mixedcaps", "dontpanic"}; !slices.Equal(c.Analyzers.Disable, want) {
+		t.Errorf("got %v want %v", c.Analyzers.Disable, want)
+	}
+	if got := c.AnalyzersSettings.Varnames.SmallVarnameMax; got != 8 {
+		t.Errorf("got %v want %v", got, 8)
+	}
+	if got := c.AnalyzersSettings.Varnames.MediumVarnameMax; got != 8 {
+		t.Errorf("got %v want %v", got, 8)
+	}
+	if !c.RequireIgnoreReason {
+		t.Error("got false want true")
+	}
+
+	if _, err := parse([]byte("preset: unknown"), ".gostyle.yml", t.TempDir(), &Config{}, nil); err == nil {
+		t.Error("want error")
+	}
+
+	// relaxed-legacy reports the findings of the style analyzers as warnings (or infos).
+	legacy, err := parse([]byte("preset: relaxed-legacy"), ".gostyle.yml", t.TempDir(), &Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := reflect.TypeOf(legacy.AnalyzersSettings)
+	for i := range rt.NumField() {
+		n := yamlName(rt.Field(i))
+		if n == "nostyle" || slices.Contains(legacy.Analyzers.Disable, n) {
+			continue
+		}
+		s, _ := legacy.settingsOf(n)
+		if ss, _ := fieldOf[Severities](s, "Severities"); ss.Severity == "" || ss.Severity == "error" {
+			t.Errorf("relaxed-legacy: %s: got severity %q want warning or info", n, ss.Severity)
+		}
+	}
+}
+
+func TestDefaultNone(t *testing.T) {
+	dir := t.TempDir()
+	c, err := parse([]byte(`
+preset: code-review-comments
+analyzers:
+  enable:
+    - varnames
+  disable:
+    - dontpanic
+overrides:
+  - paths:
+      - cmd
+    analyzers:
+      default: all
+      disable:
+        - useq
+`), ".gostyle.yml", dir, &Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.prepare(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		file     string
+		analyzer string
+		want     bool
+	}{
+		{"a.go", "contexts", false},
+		{"a.go", "varnames", false},
+		{"a.go", "dontpanic", true},
+		{"a.go", "mixedcaps", true},
+		{"cmd/a.go", "mixedcaps", false},
+		{"cmd/a.go", "dontpanic", true},
+		{"cmd/a.go", "useq", true},
+	}
+	for _, tt := range tests {
+		if got := c.For(filepath.Join(dir, tt.file)).IsDisabled(tt.analyzer); got != tt.want {
+			t.Errorf("%s %s: got %v want %v", tt.file, tt.analyzer, got, tt.want)
+		}
+	}
+}
+
+func TestEnableSources(t *testing.T) {
+	for _, m := range []meta.Meta{
+		{Name: "mixedcaps", Source: meta.SourceGuide},
+		{Name: "varnames", Source: meta.SourceDecisions},
+		{Name: "useq", Source: meta.SourceDecisions},
+		{Name: "ifacenames", Source: meta.SourceEffective},
+		{Name: "contexts", Source: meta.SourceCodeReviewComments},
+	} {
+		meta.Register(m)
+	}
+	dir := t.TempDir()
+	c, err := parse([]byte(`
+analyzers:
+  enable-sources:
+    - decisions
+  enable:
+    - contexts
+  disable:
+    - useq
+overrides:
+  - paths:
+      - cmd
+    analyzers:
+      enable-sources:
+        - effective
+`), ".gostyle.yml", dir, &Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.prepare(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		file     string
+		analyzer string
+		want     bool
+	}{
+		{"a.go", "varnames", false},
+		{"a.go", "useq", true},
+		{"a.go", "contexts", false},
+		{"a.go", "mixedcaps", true},
+		{"a.go", "ifacenames", true},
+		{"cmd/a.go", "varnames", true},
+		{"cmd/a.go", "ifacenames", false},
+		{"cmd/a.go", "contexts", false},
+	}
+	for _, tt := range tests {
+		if got := c.For(filepath.Join(dir, tt.file)).IsDisabled(tt.analyzer); got != tt.want {
+			t.Errorf("%s %s: got %v want %v", tt.file, tt.analyzer, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeTests(t *testing.T) {
+	dir := t.TempDir()
+	c, err := parse([]byte(`
+tests: false
+overrides:
+  - paths:
+      - cmd
+    analyzers-settings:
+      dontpanic:
+        exclude-test: true
+`), ".gostyle.yml", dir, &Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.prepare(); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"a.go", "cmd/a.go"} {
+		if c.For(filepath.Join(dir, f)).AnalyzeTests() {
+			t.Errorf("%s: got true want false", f)
+		}
+	}
+	// The nested config can analyze test files again.
+	nc, err := parse([]byte(`
+tests: true
+`), "sub/.gostyle.yml", filepath.Join(dir, "sub"), c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nc.AnalyzeTests() {
+		t.Error("got false want true")
+	}
+	if c.AnalyzeTests() {
+		t.Error("the parent config is modified")
+	}
+	if !(&Config{}).AnalyzeTests() {
+		t.Error("got false want true")
+	}
+}
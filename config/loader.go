@@ -1,12 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 
-	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/gostyle/reporter"
+
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -32,26 +34,7 @@ var Loader = &analysis.Analyzer{
 func run(pass *analysis.Pass) (any, error) {
 	c := &Config{}
 	if configPath == "" {
-		wd, err := os.Getwd()
-		if err != nil {
-			return nil, err
-		}
-	L:
-		for {
-			if _, err := os.Stat(filepath.Join(wd, ".git", "config")); err == nil {
-				for _, n := range defaultFileNames {
-					p := filepath.Join(wd, n)
-					if _, err := os.Stat(p); err == nil {
-						configPath = p
-						break L
-					}
-				}
-			}
-			if wd == filepath.Dir(wd) {
-				break
-			}
-			wd = filepath.Dir(wd)
-		}
+		configPath = find()
 		if configPath == "" {
 			return c, nil
 		}
@@ -60,19 +43,99 @@ func run(pass *analysis.Pass) (any, error) {
 		c.err = fmt.Errorf("config file path must be absolute path: %s", configPath)
 		return c, nil
 	}
-	f, err := os.Open(configPath)
+	nc, err := loadRoot(configPath)
 	if err != nil {
-		c.err = fmt.Errorf("failed to open config file: %w", err)
+		c.err = err
 		return c, nil
 	}
-	defer f.Close()
-	if err := yaml.NewDecoder(f).Decode(c); err != nil {
-		c.err = fmt.Errorf("failed to decode config file: %w", err)
-		return c, nil
+	return nc, nil
+}
+
+// find returns the path of the config file.
+func find() string {
+	if configPath != "" {
+		return configPath
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(wd, ".git", "config")); err == nil {
+			for _, n := range defaultFileNames {
+				p := filepath.Join(wd, n)
+				if _, err := os.Stat(p); err == nil {
+					return p
+				}
+			}
+		}
+		if wd == filepath.Dir(wd) {
+			return ""
+		}
+		wd = filepath.Dir(wd)
 	}
-	c.ConfigDir = filepath.Dir(configPath)
+}
+
+// ErrNotFound is returned when the config file is not found.
+var ErrNotFound = errors.New("config file not found")
 
-	// Set default value
+// Open loads the config file that applies to its directory tree.
+// If p is empty, the config file is searched in the same way as the loader.
+func Open(p string) (*Config, error) {
+	if p == "" {
+		p = find()
+		if p == "" {
+			return nil, ErrNotFound
+		}
+	}
+	if !filepath.IsAbs(p) {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		p = abs
+	}
+	return loadRoot(p)
+}
+
+// Defaults returns the config with the default values (used when no config file is found).
+func Defaults() *Config {
+	c := &Config{
+		loaded:     true,
+		overridden: &overridden{configs: map[string]*Config{}},
+	}
+	c.setDefaults()
+	return c
+}
+
+// loadRoot loads the config file that applies to its directory tree.
+func loadRoot(p string) (*Config, error) {
+	c, err := load(p, &Config{}, []string{p})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.prepare(); err != nil {
+		return nil, err
+	}
+	c.nested = &nested{configs: map[string]*Config{}}
+	return c, nil
+}
+
+// prepare sets the default values and validates the config.
+func (c *Config) prepare() error {
+	c.overridden = &overridden{configs: map[string]*Config{}}
+	c.setDefaults()
+	for i := range c.Overrides {
+		if _, err := c.override([]int{i}); err != nil {
+			return err
+		}
+	}
+	c.loaded = true
+	return nil
+}
+
+// setDefaults sets the default values.
+func (c *Config) setDefaults() {
 	if c.AnalyzersSettings.Recvnames.Max == 0 {
 		c.AnalyzersSettings.Recvnames.Max = DefaultReceiverNameMax
 	}
@@ -97,9 +160,12 @@ func run(pass *analysis.Pass) (any, error) {
 	if c.AnalyzersSettings.Varnames.VeryLargeVarnameMax == 0 {
 		c.AnalyzersSettings.Varnames.VeryLargeVarnameMax = DefaultVeryLargeVarnameMax
 	}
-
-	c.loaded = true
-	return c, nil
+	rv := reflect.ValueOf(&c.AnalyzersSettings).Elem()
+	for i := range rv.NumField() {
+		if s := rv.Field(i).FieldByName("Severity"); s.IsValid() && s.String() == "" {
+			s.SetString(reporter.SeverityError)
+		}
+	}
 }
 
 func SetPath(p string) {
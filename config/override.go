@@ -0,0 +1,240 @@
+package config
+
+import (
+	"fmt"
+	"go/ast"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/reporter"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Override is the settings merged over the base config for the files matching the paths.
+type Override struct {
+	Paths             []string    `yaml:"paths" desc:"paths (globbing or directories relative to the config file) to override the settings for"`
+	Analyzers         Analyzers   `yaml:"analyzers" desc:"analyzers to disable or enable for the paths"`
+	AnalyzersSettings RawSettings `yaml:"analyzers-settings" desc:"settings of analyzers for the paths (only the specified keys are overridden)"`
+	// dir is the directory of the config file that defines the override.
+	dir string
+	// source is the name of the config file that defines the override.
+	source string
+}
+
+// RawSettings is the analyzers-settings kept as YAML, to merge only the specified keys over the base settings.
+type RawSettings []byte
+
+// UnmarshalYAML keeps the YAML as is.
+func (s *RawSettings) UnmarshalYAML(b []byte) error {
+	*s = append(RawSettings(nil), b...)
+	return nil
+}
+
+// MarshalYAML returns the settings as the YAML mapping.
+func (s RawSettings) MarshalYAML() (any, error) { //nostyle:recvtype // the encoder calls the method of values only with the value receiver
+	if len(s) == 0 {
+		return nil, nil
+	}
+	var v yaml.MapSlice
+	if err := yaml.UnmarshalWithOptions(s, &v, yaml.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// overridden is the cache of the effective configs keyed by the indexes of the matched overrides.
+type overridden struct {
+	configs map[string]*Config
+	mu      sync.Mutex
+}
+
+// For returns the effective config for the file.
+// The config in the nearest directory of the file is used, and the overrides whose paths match the file are merged over it in order.
+func (c *Config) For(filename string) *Config {
+	nc := c.nearest(filepath.Dir(filename))
+	if nc.err != nil {
+		return nc
+	}
+	return nc.overridesFor(filename)
+}
+
+// ForFile returns the effective config for the file, or the error of the config (e.g. the invalid nested config file).
+func (c *Config) ForFile(filename string) (*Config, error) {
+	ec := c.For(filename)
+	if ec.err != nil {
+		return nil, ec.err
+	}
+	return ec, nil
+}
+
+// overridesFor returns the config with the overrides whose paths match the file merged.
+func (c *Config) overridesFor(filename string) *Config {
+	var idx []int
+	for i := range c.Overrides {
+		if c.Overrides[i].match(filename) {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return c
+	}
+	if c.overridden == nil {
+		ec, err := c.override(idx)
+		if err != nil {
+			return &Config{err: err}
+		}
+		return ec
+	}
+	key := fmt.Sprint(idx)
+	c.overridden.mu.Lock()
+	defer c.overridden.mu.Unlock()
+	if ec, ok := c.overridden.configs[key]; ok {
+		return ec
+	}
+	ec, err := c.override(idx)
+	if err != nil {
+		// The overrides are validated when the config is loaded.
+		ec = &Config{err: err}
+	}
+	c.overridden.configs[key] = ec
+	return ec
+}
+
+// Settings is the settings of an analyzer resolved for a group of files.
+type Settings[T any] struct {
+	// Analyzer is the analyzers-settings of the analyzer (or the values of the flags if the config is not loaded).
+	Analyzer T
+	// Initialisms is the table of initialisms.
+	Initialisms *detector.Initialisms
+	// Options is the options of the reporter.
+	Options []reporter.Option
+	// Config is the effective config of the files (nil if the config is not loaded).
+	Config *Config
+}
+
+// Each calls fn with the settings of the analyzer for each group of files in the pass that share the same effective config.
+// The settings may differ by files (overrides), so they are passed to fn instead of overwriting the package-level values of the flags.
+// If the config is not loaded, fn is called once with disable and flags (the values of the flags).
+// fn is not called for the files for which the analyzer is disabled.
+func Each[T any](pass *analysis.Pass, name string, disable bool, flags T, fn func(pass *analysis.Pass, s *Settings[T]) error) error {
+	return each(pass, func(pass *analysis.Pass, c *Config) error {
+		if c == nil {
+			if disable {
+				return nil
+			}
+			v := reflect.ValueOf(flags)
+			gen, _ := fieldOf[bool](v, "IncludeGenerated")
+			ex, _ := fieldOf[Excludes](v, "Excludes")
+			return fn(pass, &Settings[T]{
+				Analyzer:    flags,
+				Initialisms: detector.DefaultInitialisms,
+				Options:     reporter.FileOptions(gen, ex.ExcludeTest),
+			})
+		}
+		if c.IsDisabled(name) {
+			return nil
+		}
+		s := &Settings[T]{
+			Initialisms: c.Initialisms.Table(),
+			Options:     c.ReporterOptions(name),
+			Config:      c,
+		}
+		if v, ok := c.settingsOf(name); ok {
+			s.Analyzer, _ = v.Interface().(T)
+		}
+		return fn(pass, s)
+	})
+}
+
+// each calls fn for each group of files in the pass that share the same effective config.
+// If the config is not loaded, fn is called once with nil config.
+func each(pass *analysis.Pass, fn func(pass *analysis.Pass, c *Config) error) error {
+	c, err := Load(pass)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fn(pass, c)
+	}
+	var configs []*Config
+	files := map[*Config][]*ast.File{}
+	for _, f := range pass.Files {
+		ec := c.For(pass.Fset.File(f.Pos()).Name())
+		if ec.err != nil {
+			return ec.err
+		}
+		if _, ok := files[ec]; !ok {
+			configs = append(configs, ec)
+		}
+		files[ec] = append(files[ec], f)
+	}
+	if len(configs) == 1 {
+		return fn(pass, configs[0])
+	}
+	for _, ec := range configs {
+		p := *pass
+		p.Files = files[ec]
+		p.ResultOf = maps.Clone(pass.ResultOf)
+		p.ResultOf[inspect.Analyzer] = inspector.New(p.Files)
+		if err := fn(&p, ec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// override returns a copy of the config with the overrides merged.
+// The analyzers disabled and enabled by the overrides are merged in the same way as the extended config.
+// The keys of analyzers-settings specified by the overrides replace the base values (lists and maps are not merged).
+func (c *Config) override(idx []int) (*Config, error) {
+	ec := &Config{
+		Analyzers:           c.Analyzers,
+		AnalyzersSettings:   c.AnalyzersSettings,
+		ExcludeFiles:        c.ExcludeFiles,
+		RequireIgnoreReason: c.RequireIgnoreReason,
+		Tests:               c.Tests,
+		Initialisms:         c.Initialisms,
+		ConfigDir:           c.ConfigDir,
+		loaded:              c.loaded,
+	}
+	ec.sources = slices.Clone(c.sources)
+	for _, i := range idx {
+		o := c.Overrides[i]
+		ec.sources = append(ec.sources, fmt.Sprintf("%s: overrides (paths: %s)", o.source, strings.Join(o.Paths, ", ")))
+		ec.Analyzers = mergeAnalyzers(ec.Analyzers, o.Analyzers)
+		if len(o.AnalyzersSettings) == 0 {
+			continue
+		}
+		if err := yaml.Unmarshal(o.AnalyzersSettings, &ec.AnalyzersSettings); err != nil {
+			return nil, fmt.Errorf("invalid analyzers-settings of overrides[%d]: %w", i, err)
+		}
+		ec.AnalyzersSettings.resolve(o.dir)
+	}
+	return ec, nil
+}
+
+func (o *Override) match(filename string) bool {
+	for _, p := range o.Paths {
+		match, err := doublestar.PathMatch(filepath.Join(o.dir, p), filename)
+		if err != nil {
+			continue
+		}
+		if match {
+			return true
+		}
+		// A directory matches the files under it.
+		if strings.HasPrefix(filename, filepath.Join(o.dir, p)+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
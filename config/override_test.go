@@ -0,0 +1,75 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const overridesConfig = `
+analyzers:
+  disable:
+    - mixedcaps
+analyzers-settings:
+  varnames:
+    small-scope-max: 7
+    small-varname-max: 4
+overrides:
+  - paths:
+      - internal/legacy
+    analyzers:
+      disable:
+        - varnames
+  - paths:
+      - "cmd/**/*.go"
+    analyzers:
+      enable:
+        - mixedcaps
+    analyzers-settings:
+      varnames:
+        small-varname-max: 8
+`
+
+func TestFor(t *testing.T) {
+	dir := t.TempDir()
+	c, err := parse([]byte(overridesConfig), ".gostyle.yml", dir, &Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.prepare(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		file            string
+		wantDisable     []string
+		smallScopeMax   int
+		smallVarnameMax int
+	}{
+		{"main.go", []string{"mixedcaps"}, 7, 4},
+		{"internal/legacy/a.go", []string{"mixedcaps", "varnames"}, 7, 4},
+		{"internal/legacy/sub/a.go", []string{"mixedcaps", "varnames"}, 7, 4},
+		{"internal/legacyx/a.go", []string{"mixedcaps"}, 7, 4},
+		{"cmd/root.go", nil, 7, 8},
+		{"cmd/sub/root.go", nil, 7, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			ec := c.For(filepath.Join(dir, tt.file))
+			if ec.err != nil {
+				t.Fatal(ec.err)
+			}
+			if !slices.Equal(ec.Analyzers.Disable, tt.wantDisable) {
+				t.Errorf("got %v want %v", ec.Analyzers.Disable, tt.wantDisable)
+			}
+			if got := ec.AnalyzersSettings.Varnames.SmallScopeMax; got != tt.smallScopeMax {
+				t.Errorf("got %v want %v", got, tt.smallScopeMax)
+			}
+			if got := ec.AnalyzersSettings.Varnames.SmallVarnameMax; got != tt.smallVarnameMax {
+				t.Errorf("got %v want %v", got, tt.smallVarnameMax)
+			}
+		})
+	}
+	if got := c.AnalyzersSettings.Varnames.SmallVarnameMax; got != 4 {
+		t.Errorf("the base config is modified: got %v want %v", got, 4)
+	}
+}
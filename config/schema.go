@@ -0,0 +1,167 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+	"golang.org/x/tools/go/analysis"
+)
+
+const settingsRef = "#/$defs/analyzers-settings"
+
+// Schema returns the JSON Schema of the config file.
+// The descriptions and the defaults of the settings are taken from the flags of the analyzers (or the desc and default tags).
+func Schema(analyzers []*analysis.Analyzer) ([]byte, error) {
+	g := &schemaGenerator{analyzers: analyzers}
+	root := g.object(reflect.TypeOf(Config{}), nil)
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = "gostyle config"
+	root["$defs"] = map[string]any{
+		"analyzers-settings": g.object(reflect.TypeOf(AnalyzersSettings{}), nil),
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+type schemaGenerator struct {
+	analyzers []*analysis.Analyzer
+}
+
+// object returns the schema of the struct. a is the analyzer whose settings the struct is.
+func (g *schemaGenerator) object(t reflect.Type, a *analysis.Analyzer) map[string]any {
+	return map[string]any{
+		"type":                 "object",
+		"properties":           g.properties(t, a),
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGenerator) properties(t reflect.Type, a *analysis.Analyzer) map[string]any {
+	props := map[string]any{}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("yaml")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		if strings.Contains(tag, "inline") {
+			for k, v := range g.properties(sf.Type, a) {
+				props[k] = v
+			}
+			continue
+		}
+		name := yamlName(sf)
+		fa := a
+		if t == reflect.TypeOf(AnalyzersSettings{}) {
+			fa = g.analyzer(name)
+		}
+		s := g.schema(sf.Type, fa)
+		switch name {
+		case "preset":
+			s["enum"] = Presets()
+		case "default":
+			s["enum"] = []string{AnalyzersDefaultAll, AnalyzersDefaultNone}
+		case "severity":
+			s["enum"] = reporter.Severities
+		case "kind-severity":
+			s["additionalProperties"] = map[string]any{"type": "string", "enum": reporter.Severities}
+		case "disable", "enable":
+			s["items"] = map[string]any{"type": "string", "enum": Names()}
+		case "enable-sources":
+			s["items"] = map[string]any{"type": "string", "enum": meta.Sources}
+		}
+		if fa != nil && t == reflect.TypeOf(AnalyzersSettings{}) {
+			s["description"] = fa.Doc
+		}
+		g.describe(s, sf, name, a)
+		props[name] = s
+	}
+	return props
+}
+
+// describe sets the description and the default of the field from the desc and default tags or the flag of the analyzer.
+func (g *schemaGenerator) describe(s map[string]any, sf reflect.StructField, name string, a *analysis.Analyzer) {
+	if d, ok := sf.Tag.Lookup("desc"); ok {
+		s["description"] = d
+		if v, ok := sf.Tag.Lookup("default"); ok {
+			if dv, ok := defaultValue(sf.Type, v); ok {
+				s["default"] = dv
+			}
+		}
+		return
+	}
+	var candidates []*analysis.Analyzer
+	if a != nil {
+		candidates = []*analysis.Analyzer{a}
+	} else {
+		candidates = g.analyzers
+	}
+	for _, c := range candidates {
+		f := c.Flags.Lookup(name)
+		if f == nil {
+			continue
+		}
+		s["description"] = f.Usage
+		if v, ok := defaultValue(sf.Type, f.DefValue); ok {
+			s["default"] = v
+		}
+		return
+	}
+}
+
+func (g *schemaGenerator) schema(t reflect.Type, a *analysis.Analyzer) map[string]any {
+	if t == reflect.TypeOf(RawSettings(nil)) || t == reflect.TypeOf(AnalyzersSettings{}) {
+		return map[string]any{"$ref": settingsRef}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem(), a)
+	case reflect.Struct:
+		return g.object(t, a)
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem(), a)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem(), a)}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int:
+		return map[string]any{"type": "integer"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func (g *schemaGenerator) analyzer(name string) *analysis.Analyzer {
+	for _, a := range g.analyzers {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// defaultValue converts the default value of the flag to the value of the type.
+func defaultValue(t reflect.Type, v string) (any, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	case reflect.Int:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	case reflect.Slice:
+		// The flags of lists are comma separated.
+		if v == "" {
+			return nil, false
+		}
+		return strings.Split(v, ","), true
+	default:
+		return v, v != ""
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestSchema(t *testing.T) {
+	a := &analysis.Analyzer{Name: "varnames", Doc: "varnames doc"}
+	a.Flags.Int("small-scope-max", DefaultSmallScopeMax, "max lines for small scope")
+	a.Flags.String("exclude", "", "exclude words (comma separated)")
+	n := &analysis.Analyzer{Name: "nostyle", Doc: "nostyle doc"}
+	n.Flags.Bool("require-ignore-reason", false, "require the reason")
+
+	b, err := Schema([]*analysis.Analyzer{a, n})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Properties map[string]struct {
+			Description string `json:"description"`
+			Default     any    `json:"default"`
+		} `json:"properties"`
+		Defs struct {
+			Settings struct {
+				Properties map[string]struct {
+					Description string `json:"description"`
+					Properties  map[string]struct {
+						Description string `json:"description"`
+						Default     any    `json:"default"`
+						Type        string `json:"type"`
+					} `json:"properties"`
+				} `json:"properties"`
+			} `json:"analyzers-settings"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Properties["require-ignore-reason"]; got.Description != "require the reason" || got.Default != false {
+		t.Errorf("got %v", got)
+	}
+	for _, name := range Names() {
+		if _, ok := s.Defs.Settings.Properties[name]; !ok {
+			t.Errorf("%s is not in the schema", name)
+		}
+	}
+	vn := s.Defs.Settings.Properties["varnames"]
+	if vn.Description != "varnames doc" {
+		t.Errorf("got %q want %q", vn.Description, "varnames doc")
+	}
+	if got := vn.Properties["small-scope-max"]; got.Description != "max lines for small scope" || got.Default != float64(DefaultSmallScopeMax) || got.Type != "integer" {
+		t.Errorf("got %v", got)
+	}
+	if got := vn.Properties["exclude"]; got.Default != nil || got.Type != "array" {
+		t.Errorf("got %v", got)
+	}
+	if got := vn.Properties["severity"]; got.Default != "error" {
+		t.Errorf("got %v", got)
+	}
+}
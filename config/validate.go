@@ -0,0 +1,406 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+)
+
+// ValidationError is an error of the config file with the position.
+type ValidationError struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// Validate validates the config file and the nested config files in its directory tree.
+// If p is empty, the config file is searched in the same way as the loader.
+func Validate(p string) error {
+	c, err := Open(p)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	if err := filepath.WalkDir(c.ConfigDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		// The directories ignored by the go command are skipped.
+		if path != c.ConfigDir && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_") || d.Name() == "testdata") {
+			return filepath.SkipDir
+		}
+		if nc := c.nearest(path); nc.err != nil && !slices.Contains(errs, nc.err) {
+			errs = append(errs, nc.err)
+			// The nested config files under the invalid config file are not loaded.
+			return filepath.SkipDir
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// validate validates the keys and values of the config (decoded as c) strictly.
+func validate(name string, b []byte, c *Config) error {
+	f, err := parser.ParseBytes(b, 0)
+	if err != nil {
+		return positioned(name, err)
+	}
+	v := &validator{name: name}
+	for _, doc := range f.Docs {
+		v.keys(doc.Body, reflect.TypeOf(Config{}))
+	}
+	if len(v.errs) > 0 {
+		// The values cannot be validated with unknown keys.
+		return errors.Join(v.errs...)
+	}
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return positioned(name, err)
+	}
+
+	if c.Preset != "" {
+		if _, err := preset(c.Preset); err != nil {
+			v.invalid(f, "$.preset", err.Error())
+		}
+	}
+	v.analyzers(f, "$.analyzers", c.Analyzers)
+	v.initialisms(f, "$.initialisms", c.Initialisms)
+	v.settings(f, "$.analyzers-settings", c.AnalyzersSettings)
+	for i, o := range c.Overrides {
+		p := fmt.Sprintf("$.overrides[%d]", i)
+		if len(o.Paths) == 0 {
+			v.invalid(f, p, "overrides requires paths")
+		}
+		v.analyzers(f, p+".analyzers", o.Analyzers)
+		var s AnalyzersSettings
+		if len(o.AnalyzersSettings) > 0 {
+			if err := yaml.Unmarshal(o.AnalyzersSettings, &s); err != nil {
+				v.invalid(f, p+".analyzers-settings", err.Error())
+				continue
+			}
+		}
+		v.settings(f, p+".analyzers-settings", s)
+	}
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	name string
+	errs []error
+}
+
+// keys reports the unknown keys of the node for the type.
+func (v *validator) keys(n ast.Node, t reflect.Type) {
+	if t == reflect.TypeOf(RawSettings(nil)) {
+		t = reflect.TypeOf(AnalyzersSettings{})
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch n := n.(type) {
+	case *ast.MappingNode:
+		for _, mv := range n.Values {
+			v.key(mv, t)
+		}
+	case *ast.MappingValueNode:
+		v.key(n, t)
+	case *ast.SequenceNode:
+		if t.Kind() != reflect.Slice {
+			return
+		}
+		for _, e := range n.Values {
+			v.keys(e, t.Elem())
+		}
+	}
+}
+
+func (v *validator) key(mv *ast.MappingValueNode, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Map:
+		v.keys(mv.Value, t.Elem())
+	case reflect.Struct:
+		k := mv.Key.String()
+		fields := yamlFields(t)
+		ft, ok := fields[k]
+		if !ok {
+			msg := fmt.Sprintf("unknown key %q", k)
+			if s := suggest(k, slices.Sorted(maps.Keys(fields))); s != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", s)
+			}
+			tk := mv.Key.GetToken()
+			v.errs = append(v.errs, &ValidationError{File: v.name, Line: tk.Position.Line, Column: tk.Position.Column, Msg: msg})
+			return
+		}
+		v.keys(mv.Value, ft)
+	}
+}
+
+// analyzers validates the default, the names of analyzers and the style sources.
+func (v *validator) analyzers(f *ast.File, p string, a Analyzers) {
+	if a.Default != "" && a.Default != AnalyzersDefaultAll && a.Default != AnalyzersDefaultNone {
+		v.invalid(f, p+".default", fmt.Sprintf("invalid default %q (must be %s or %s)", a.Default, AnalyzersDefaultAll, AnalyzersDefaultNone))
+	}
+	for i, n := range a.Enable {
+		if slices.Contains(a.Disable, n) {
+			v.invalid(f, fmt.Sprintf("%s.enable[%d]", p, i), fmt.Sprintf("analyzer %q is both enabled and disabled", n))
+		}
+	}
+	for i, src := range a.EnableSources {
+		if !slices.Contains(meta.Sources, src) {
+			msg := fmt.Sprintf("unknown source %q", src)
+			if s := suggest(src, meta.Sources); s != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", s)
+			}
+			v.invalid(f, fmt.Sprintf("%s.enable-sources[%d]", p, i), msg)
+		}
+	}
+	known := Names()
+	for i, n := range a.Disable {
+		if !slices.Contains(known, n) {
+			v.invalid(f, fmt.Sprintf("%s.disable[%d]", p, i), unknownAnalyzer(n, known))
+		}
+	}
+	for i, n := range a.Enable {
+		if !slices.Contains(known, n) {
+			v.invalid(f, fmt.Sprintf("%s.enable[%d]", p, i), unknownAnalyzer(n, known))
+		}
+	}
+}
+
+// initialisms validates the initialisms to add and remove.
+func (v *validator) initialisms(f *ast.File, p string, in InitialismsTable) {
+	for i, w := range in.Add {
+		if w == "" || strings.ContainsFunc(w, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			v.invalid(f, fmt.Sprintf("%s.add[%d]", p, i), fmt.Sprintf("invalid initialism %q (must consist of letters and digits)", w))
+		}
+	}
+	for i, w := range in.Remove {
+		if _, ok := detector.DefaultInitialisms.Lookup(w); !ok {
+			v.invalid(f, fmt.Sprintf("%s.remove[%d]", p, i), fmt.Sprintf("unknown initialism %q (not in the default initialisms)", w))
+		}
+	}
+}
+
+// settings validates the values of analyzers-settings.
+func (v *validator) settings(f *ast.File, p string, s AnalyzersSettings) {
+	rv := reflect.ValueOf(&s).Elem()
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		name := yamlName(rt.Field(i))
+		if ex := rv.Field(i).FieldByName("Exclude"); ex.IsValid() {
+			for j, e := range ex.Interface().([]string) {
+				if _, err := detector.NewExcludes([]string{e}); err != nil {
+					v.invalid(f, fmt.Sprintf("%s.%s.exclude[%d]", p, name, j), err.Error())
+				}
+			}
+		}
+		if ex, ok := rv.Field(i).FieldByName("Excludes").Interface().(Excludes); ok {
+			for _, e := range []struct {
+				key      string
+				patterns []string
+			}{
+				{"exclude-files", ex.ExcludeFiles},
+				{"exclude-packages", ex.ExcludePackages},
+			} {
+				for j, pt := range e.patterns {
+					if !doublestar.ValidatePattern(pt) {
+						v.invalid(f, fmt.Sprintf("%s.%s.%s[%d]", p, name, e.key, j), fmt.Sprintf("invalid pattern %q", pt))
+					}
+				}
+			}
+		}
+		ss, ok := rv.Field(i).FieldByName("Severities").Interface().(Severities)
+		if !ok {
+			continue
+		}
+		if ss.Severity != "" && !slices.Contains(reporter.Severities, ss.Severity) {
+			v.invalid(f, fmt.Sprintf("%s.%s.severity", p, name), invalidSeverity(ss.Severity))
+		}
+		m, registered := meta.Of(name)
+		for _, k := range slices.Sorted(maps.Keys(ss.KindSeverity)) {
+			// The message kinds are known only if the analyzer is registered (e.g. not in the tests of config).
+			if registered && !slices.Contains(m.Kinds, k) {
+				v.invalid(f, fmt.Sprintf("%s.%s.kind-severity.%s", p, name, k), unknownKind(name, k, m.Kinds))
+				continue
+			}
+			if sev := ss.KindSeverity[k]; !slices.Contains(reporter.Severities, sev) {
+				v.invalid(f, fmt.Sprintf("%s.%s.kind-severity.%s", p, name, k), invalidSeverity(sev))
+			}
+		}
+	}
+	for i, fn := range s.Errorstrings.Constructors {
+		if _, err := detector.NewFuncs([]string{fn}); err != nil {
+			v.invalid(f, fmt.Sprintf("%s.errorstrings.constructors[%d]", p, i), err.Error())
+		}
+	}
+	if s.Recvnames.Max < 0 {
+		v.invalid(f, p+".recvnames.max", fmt.Sprintf("max must not be negative: %d", s.Recvnames.Max))
+	}
+	vn := s.Varnames
+	for _, m := range []struct {
+		key string
+		max int
+		min int
+	}{
+		{"small-scope-max", vn.SmallScopeMax, 0},
+		{"medium-scope-max", vn.MediumScopeMax, 0},
+		{"large-scope-max", vn.LargeScopeMax, 0},
+		// -1 means no limit.
+		{"small-varname-max", vn.SmallVarnameMax, -1},
+		{"medium-varname-max", vn.MediumVarnameMax, -1},
+		{"large-varname-max", vn.LargeVarnameMax, -1},
+		{"very-large-varname-max", vn.VeryLargeVarnameMax, -1},
+	} {
+		if m.max < m.min {
+			v.invalid(f, fmt.Sprintf("%s.varnames.%s", p, m.key), fmt.Sprintf("%s must be %d or more: %d", m.key, m.min, m.max))
+		}
+	}
+}
+
+// invalid reports the invalid value at the path.
+func (v *validator) invalid(f *ast.File, p, msg string) {
+	e := &ValidationError{File: v.name, Msg: msg}
+	if yp, err := yaml.PathString(p); err == nil {
+		if n, err := yp.FilterFile(f); err == nil && n != nil {
+			if m, ok := n.(*ast.MappingNode); ok && len(m.Values) > 0 {
+				// The token of the mapping is the first ':'.
+				n = m.Values[0].Key
+			}
+			tk := n.GetToken()
+			e.Line, e.Column = tk.Position.Line, tk.Position.Column
+		}
+	}
+	v.errs = append(v.errs, e)
+}
+
+// Names returns the names of analyzers that can be configured.
+func Names() []string {
+	var ns []string
+	t := reflect.TypeOf(AnalyzersSettings{})
+	for i := range t.NumField() {
+		ns = append(ns, yamlName(t.Field(i)))
+	}
+	return ns
+}
+
+func unknownAnalyzer(n string, known []string) string {
+	msg := fmt.Sprintf("unknown analyzer %q", n)
+	if s := suggest(n, known); s != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", s)
+	}
+	return msg
+}
+
+func unknownKind(analyzer, k string, kinds []string) string {
+	if len(kinds) == 0 {
+		return fmt.Sprintf("unknown message kind %q (%s has no message kinds)", k, analyzer)
+	}
+	if s := suggest(k, kinds); s != "" {
+		return fmt.Sprintf("unknown message kind %q of %s (did you mean %q?)", k, analyzer, s)
+	}
+	return fmt.Sprintf("unknown message kind %q of %s (must be one of %s)", k, analyzer, strings.Join(kinds, ", "))
+}
+
+func invalidSeverity(s string) string {
+	return fmt.Sprintf("invalid severity %q (must be one of %s)", s, strings.Join(reporter.Severities, ", "))
+}
+
+// positioned converts the YAML error to the error with the position.
+func positioned(name string, err error) error {
+	var ye yaml.Error
+	if errors.As(err, &ye) && ye.GetToken() != nil {
+		tk := ye.GetToken()
+		return &ValidationError{File: name, Line: tk.Position.Line, Column: tk.Position.Column, Msg: ye.GetMessage()}
+	}
+	return &ValidationError{File: name, Msg: err.Error()}
+}
+
+// yamlFields returns the types of the fields of the struct keyed by the YAML keys (including inline fields).
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		if strings.Contains(tag, "inline") {
+			for k, ft := range yamlFields(sf.Type) {
+				fields[k] = ft
+			}
+			continue
+		}
+		fields[yamlName(sf)] = sf.Type
+	}
+	return fields
+}
+
+func yamlName(sf reflect.StructField) string {
+	n, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+	if n == "" {
+		return strings.ToLower(sf.Name)
+	}
+	return n
+}
+
+// suggest returns the candidate most similar to s, or empty if none is similar.
+func suggest(s string, candidates []string) string {
+	var best string
+	bestDist := len(s)/3 + 2
+	for _, c := range candidates {
+		if d := distance(s, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
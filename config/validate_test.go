@@ -0,0 +1,261 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k1LoW/gostyle/meta"
+)
+
+func TestValidate(t *testing.T) {
+	// The message kinds are registered by the analyzers.
+	meta.Register(meta.Meta{Name: "recvtype", Kinds: []string{"pointer", "value"}})
+	meta.Register(meta.Meta{Name: "nilslices", Kinds: []string{"declaration", "comparison"}})
+	meta.Register(meta.Meta{Name: "dontpanic"})
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			"valid",
+			`
+analyzers:
+  disable:
+    - mixedcaps
+analyzers-settings:
+  varnames:
+    small-varname-max: -1
+  recvtype:
+    severity: warning
+    kind-severity:
+      pointer: info
+overrides:
+  - paths:
+      - cmd
+    analyzers-settings:
+      recvnames:
+        max: 3
+`,
+			nil,
+		},
+		{
+			"unknown keys",
+			`
+analyzer-settings:
+  varnames:
+    small-scope-max: 3
+analyzers:
+  disable:
+    - mixedcaps
+  disabled:
+    - varnames
+overrides:
+  - paths:
+      - cmd
+    analyzers-settings:
+      varname:
+        small-scope-max: 3
+`,
+			[]string{
+				`.gostyle.yml:2:1: unknown key "analyzer-settings" (did you mean "analyzers-settings"?)`,
+				`.gostyle.yml:8:3: unknown key "disabled" (did you mean "disable"?)`,
+				`.gostyle.yml:14:7: unknown key "varname" (did you mean "varnames"?)`,
+			},
+		},
+		{
+			"invalid values",
+			`
+analyzers:
+  disable:
+    - mixedcap
+    - foo
+analyzers-settings:
+  varnames:
+    small-scope-max: -1
+    small-varname-max: -2
+  recvnames:
+    max: -1
+  nilslices:
+    severity: fatal
+    kind-severity:
+      comparison: warn
+overrides:
+  - analyzers:
+      default: some
+      enable:
+        - mixedcaps
+      disable:
+        - mixedcaps
+`,
+			[]string{
+				`.gostyle.yml:4:7: unknown analyzer "mixedcap" (did you mean "mixedcaps"?)`,
+				`.gostyle.yml:5:7: unknown analyzer "foo"`,
+				`.gostyle.yml:13:15: invalid severity "fatal" (must be one of info, warning, error)`,
+				`.gostyle.yml:15:19: invalid severity "warn" (must be one of info, warning, error)`,
+				`.gostyle.yml:11:10: max must not be negative: -1`,
+				`.gostyle.yml:8:22: small-scope-max must be 0 or more: -1`,
+				`.gostyle.yml:9:24: small-varname-max must be -1 or more: -2`,
+				`.gostyle.yml:17:5: overrides requires paths`,
+				`.gostyle.yml:18:16: invalid default "some" (must be all or none)`,
+				`.gostyle.yml:20:11: analyzer "mixedcaps" is both enabled and disabled`,
+			},
+		},
+		{
+			"unknown source",
+			`
+analyzers:
+  enable-sources:
+    - decisions
+    - decision
+`,
+			[]string{
+				`.gostyle.yml:5:7: unknown source "decision" (did you mean "decisions"?)`,
+			},
+		},
+		{
+			"invalid exclude pattern",
+			`
+analyzers-settings:
+  getters:
+    exclude:
+      - Get*
+      - /^Get[/
+`,
+			[]string{
+				`.gostyle.yml:6:9: invalid exclude pattern "/^Get[/": error parsing regexp: missing closing ]: ` + "`[`",
+			},
+		},
+		{
+			"invalid exclude-packages pattern",
+			`
+analyzers-settings:
+  dontpanic:
+    exclude-packages:
+      - "*/internal/testutil"
+      - "*/internal/[a"
+`,
+			[]string{
+				`.gostyle.yml:6:9: invalid pattern "*/internal/[a"`,
+			},
+		},
+		{
+			"invalid initialisms",
+			`
+initialisms:
+  add:
+    - GRPC
+    - K-8S
+  remove:
+    - ID
+    - GRPC
+`,
+			[]string{
+				`.gostyle.yml:5:7: invalid initialism "K-8S" (must consist of letters and digits)`,
+				`.gostyle.yml:8:7: unknown initialism "GRPC" (not in the default initialisms)`,
+			},
+		},
+		{
+			"invalid constructors",
+			`
+analyzers-settings:
+  errorstrings:
+    constructors:
+      - github.com/pkg/errors.Wrapf:1
+      - github.com/pkg/errors
+      - xerrors.Errorf:first
+`,
+			[]string{
+				`.gostyle.yml:6:9: invalid function "github.com/pkg/errors": must be the package path and the function name (e.g. github.com/pkg/errors.Wrapf)`,
+				`.gostyle.yml:7:9: invalid function "xerrors.Errorf:first": the index of the argument must be a non-negative integer`,
+			},
+		},
+		{
+			"unknown message kinds",
+			`
+analyzers-settings:
+  nilslices:
+    kind-severity:
+      declartion: info
+      comparison: warning
+  dontpanic:
+    kind-severity:
+      panic: info
+`,
+			[]string{
+				`.gostyle.yml:9:14: unknown message kind "panic" (dontpanic has no message kinds)`,
+				`.gostyle.yml:5:19: unknown message kind "declartion" of nilslices (did you mean "declaration"?)`,
+			},
+		},
+		{
+			"invalid type",
+			`
+analyzers-settings:
+  varnames:
+    small-scope-max: abc
+`,
+			[]string{
+				`.gostyle.yml:4:22: cannot unmarshal string into Go struct field`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p := filepath.Join(dir, ".gostyle.yml")
+			if err := os.WriteFile(p, []byte(tt.in), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			err := Validate(p)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("got %v want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("want error")
+			}
+			got := strings.Split(strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), ""), "\n")
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %q want %q", got, tt.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("got %q want %q", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateNested(t *testing.T) {
+	dir := t.TempDir()
+	for n, b := range map[string]string{
+		".gostyle.yml":         "analyzers:\n  disable:\n    - mixedcaps\n",
+		"a/.gostyle.yml":       "analyzers:\n  disable:\n    - varname\n",
+		"b/c/.gostyle.yml":     "extends: ../../base.yml\n",
+		"base.yml":             "preset: strict\n",
+		".hidden/.gostyle.yml": "foo: bar\n",
+	} {
+		p := filepath.Join(dir, n)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(b), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := Validate(filepath.Join(dir, ".gostyle.yml"))
+	if err == nil {
+		t.Fatal("want error")
+	}
+	got := strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), "")
+	want := `a/.gostyle.yml:3:7: unknown analyzer "varname" (did you mean "varnames"?)
+base.yml:1:9: unknown preset: strict (available: default, code-review-comments, effective-go, google-strict, relaxed-legacy)`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
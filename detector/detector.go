@@ -3,16 +3,9 @@ package detector
 import (
 	"strconv"
 	"strings"
+	"unicode"
 )
 
-var initialismsRep *strings.Replacer = func() *strings.Replacer {
-	var r []string
-	for k := range initialisms {
-		r = append(r, k, "")
-	}
-	return strings.NewReplacer(r...)
-}()
-
 var numRep *strings.Replacer = func() *strings.Replacer {
 	var r []string
 	for i := 0; i <= 9; i++ {
@@ -21,14 +14,29 @@ var numRep *strings.Replacer = func() *strings.Replacer {
 	return strings.NewReplacer(r...)
 }()
 
+// IsMixedCaps reports whether s is MixedCaps or mixedCaps with the default initialisms.
 func IsMixedCaps(s string) bool {
+	return DefaultInitialisms.IsMixedCaps(s)
+}
+
+// IsMixedCaps reports whether s is MixedCaps or mixedCaps.
+// A name consisting of only initialisms (e.g. ID) is MixedCaps, but other all uppercase names (e.g. MAXLENGTH) are not.
+func (in *Initialisms) IsMixedCaps(s string) bool {
 	s = strings.TrimPrefix(s, "_")
 	if strings.Contains(s, "_") {
 		return false
 	}
-	s = initialismsRep.Replace(s)
-	s = numRep.Replace(s)
-	if len(s) > 1 && strings.ToUpper(s) == s {
+	var rest strings.Builder
+	for _, w := range in.SplitWords(s) {
+		if _, ok := in.Lookup(w); ok {
+			continue
+		}
+		if _, ok := in.Lookup(numRep.Replace(w)); ok {
+			continue
+		}
+		rest.WriteString(numRep.Replace(w))
+	}
+	if rest.Len() > 1 && strings.ToUpper(rest.String()) == rest.String() {
 		return false
 	}
 	return true
@@ -41,6 +49,89 @@ func NoUnderscore(s string) bool {
 	return !strings.Contains(s, "_")
 }
 
+// HasGetPrefix reports whether the first word of s is "Get" (or "get") with the default initialisms.
 func HasGetPrefix(s string) bool {
-	return strings.HasPrefix(strings.ToLower(s), "get")
+	return DefaultInitialisms.HasGetPrefix(s)
+}
+
+// HasGetPrefix reports whether the first word of s is "Get" (or "get").
+// Names whose first word only starts with "get" (e.g. Getaway) do not have the prefix.
+func (in *Initialisms) HasGetPrefix(s string) bool {
+	ws := in.SplitWords(s)
+	return len(ws) > 0 && strings.EqualFold(ws[0], "get")
+}
+
+// MixedCaps converts a name containing underscores to MixedCaps or mixedCaps with the default initialisms.
+func MixedCaps(s string) string {
+	return DefaultInitialisms.MixedCaps(s)
+}
+
+// MixedCaps converts a name containing underscores to MixedCaps or mixedCaps.
+// Words that are initialisms are recased according to the initialisms table (e.g. user_id -> userID),
+// and so are plurals of them (e.g. user_ids -> userIDs).
+// The leading underscore and the exportedness of the name are preserved.
+func (in *Initialisms) MixedCaps(s string) string {
+	var prefix string
+	if strings.HasPrefix(s, "_") {
+		prefix = "_"
+		s = strings.TrimPrefix(s, "_")
+	}
+	if s == "" || !strings.Contains(s, "_") {
+		return prefix + s
+	}
+	exported := unicode.IsUpper([]rune(s)[0])
+	words := in.SplitWords(s)
+	var b strings.Builder
+	b.WriteString(prefix)
+	for i, w := range words {
+		if k, ok := in.lookupPlural(w); ok {
+			if i == 0 && !exported {
+				b.WriteString(strings.ToLower(k))
+			} else {
+				b.WriteString(k)
+			}
+			continue
+		}
+		if isUpper(w) {
+			w = strings.ToLower(w)
+		}
+		if i == 0 && !exported {
+			b.WriteString(lowerFirst(w))
+			continue
+		}
+		b.WriteString(upperFirst(w))
+	}
+	return b.String()
+}
+
+// lookupPlural is like Lookup but also accepts the plural of an initialism (e.g. ids -> IDs).
+func (in *Initialisms) lookupPlural(s string) (string, bool) {
+	if k, ok := in.Lookup(s); ok {
+		return k, true
+	}
+	stem, ok := strings.CutSuffix(strings.ToLower(s), "s")
+	if !ok || stem == "" {
+		return "", false
+	}
+	k, ok := in.Lookup(stem)
+	if !ok {
+		return "", false
+	}
+	return k + "s", true
+}
+
+func isUpper(s string) bool {
+	return strings.ToUpper(s) == s && strings.ToLower(s) != s
+}
+
+func upperFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
 }
@@ -13,6 +13,10 @@ func TestIsMixedCaps(t *testing.T) {
 		{"Snake_Case", false},
 		{"userID", true},
 		{"a", true},
+		{"userIDs", true},
+		{"URLs", true},
+		{"IDS", false},
+		{"URLS", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.in, func(t *testing.T) {
@@ -22,3 +26,88 @@ func TestIsMixedCaps(t *testing.T) {
 		})
 	}
 }
+
+func TestMixedCaps(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"mixedCaps", "mixedCaps"},
+		{"MAXLENGTH", "MAXLENGTH"},
+		{"user_id", "userID"},
+		{"User_id", "UserID"},
+		{"user_ids", "userIDs"},
+		{"User_IDS", "UserIDs"},
+		{"ids_list", "idsList"},
+		{"MAX_LENGTH", "MaxLength"},
+		{"go_Pher", "goPher"},
+		{"f_a", "fA"},
+		{"Test_B", "TestB"},
+		{"http_server", "httpServer"},
+		{"get_url", "getURL"},
+		{"_foo_bar", "_fooBar"},
+		{"oauth_token", "oauthToken"},
+		{"new_oauth_token", "newOAuthToken"},
+		{"sha256_sum", "sha256Sum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := MixedCaps(tt.in); got != tt.want {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitialisms(t *testing.T) {
+	in := NewInitialisms([]string{"GRPC", "AWS", "K8S"}, []string{"id"})
+	tests := []struct {
+		in            string
+		wantDefault   bool
+		wantCustomize bool
+	}{
+		{"AWS", false, true},
+		{"GRPCServer", true, true},
+		{"K8S", false, true},
+		{"ID", true, false},
+		{"URL", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := IsMixedCaps(tt.in); got != tt.wantDefault {
+				t.Errorf("got %v want %v", got, tt.wantDefault)
+			}
+			if got := in.IsMixedCaps(tt.in); got != tt.wantCustomize {
+				t.Errorf("got %v want %v", got, tt.wantCustomize)
+			}
+		})
+	}
+	if got := in.MixedCaps("user_id"); got != "userId" {
+		t.Errorf("got %v want %v", got, "userId")
+	}
+	if got := in.MixedCaps("new_grpc_client"); got != "newGRPCClient" {
+		t.Errorf("got %v want %v", got, "newGRPCClient")
+	}
+}
+
+func TestHasGetPrefix(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"GetName", true},
+		{"getName", true},
+		{"Get", true},
+		{"GetURL", true},
+		{"Getaway", false},
+		{"getter", false},
+		{"Name", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := HasGetPrefix(tt.in); got != tt.want {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
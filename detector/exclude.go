@@ -0,0 +1,150 @@
+package detector
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ExcludeUsage is the usage of the exclude flag of the analyzers.
+const ExcludeUsage = "exclude words (comma separated). glob patterns (Get*), regular expressions (/^Test.*_/) and package scoped words (pkg/api:GetHTTPStatus) are also supported"
+
+// Excludes is the list of the patterns of names excluded from analysis.
+//
+// A pattern is one of:
+//   - a name (e.g. GetHTTPStatus)
+//   - a glob pattern (e.g. Get*)
+//   - a regular expression enclosed in slashes (e.g. /^Test.*_/)
+//
+// A pattern can be scoped to a package with the package path and a colon (e.g. pkg/api:GetHTTPStatus).
+// The package path matches the import path in the same way as MatchPackage.
+type Excludes struct {
+	patterns []excludePattern
+}
+
+type excludePattern struct {
+	pkg  string
+	name string
+	re   *regexp.Regexp
+}
+
+// NewExcludes parses the patterns of names. Empty patterns are ignored.
+func NewExcludes(patterns []string) (*Excludes, error) {
+	e := &Excludes{}
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+		ep, err := parseExclude(p)
+		if err != nil {
+			return nil, err
+		}
+		e.patterns = append(e.patterns, ep)
+	}
+	return e, nil
+}
+
+// SplitExcludes splits the comma separated patterns (the value of the exclude flag).
+// A comma in a regular expression enclosed in slashes (e.g. /^A{1,3}$/) does not separate the patterns.
+func SplitExcludes(s string) []string {
+	var (
+		patterns []string
+		fields   []string
+	)
+	for _, f := range strings.Split(s, ",") {
+		fields = append(fields, f)
+		p := strings.Join(fields, ",")
+		if unclosedRegexp(p) {
+			continue
+		}
+		patterns = append(patterns, p)
+		fields = nil
+	}
+	if len(fields) > 0 {
+		patterns = append(patterns, strings.Join(fields, ","))
+	}
+	return patterns
+}
+
+// unclosedRegexp reports whether the pattern (optionally scoped to a package) starts a regular expression that is not closed yet.
+func unclosedRegexp(p string) bool {
+	if !strings.HasPrefix(p, "/") {
+		if _, n, ok := strings.Cut(p, ":"); ok {
+			p = n
+		}
+	}
+	return strings.HasPrefix(p, "/") && !isRegexp(p)
+}
+
+func parseExclude(pattern string) (excludePattern, error) {
+	var ep excludePattern
+	p := pattern
+	// A regular expression may contain a colon, so only a package path before it is cut.
+	if !isRegexp(p) {
+		if pkg, n, ok := strings.Cut(p, ":"); ok {
+			ep.pkg, p = pkg, n
+		}
+	}
+	if ep.pkg != "" && !doublestar.ValidatePattern(ep.pkg) {
+		return ep, fmt.Errorf("invalid exclude pattern %q: %w", pattern, doublestar.ErrBadPattern)
+	}
+	if isRegexp(p) {
+		re, err := regexp.Compile(p[1 : len(p)-1])
+		if err != nil {
+			return ep, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		ep.re = re
+		return ep, nil
+	}
+	if _, err := path.Match(p, ""); err != nil {
+		return ep, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+	}
+	ep.name = p
+	return ep, nil
+}
+
+// Match reports whether the name in the package (import path) is excluded.
+func (e *Excludes) Match(pkgPath, name string) bool {
+	for _, ep := range e.patterns {
+		if ep.pkg != "" && !MatchPackage(ep.pkg, pkgPath) {
+			continue
+		}
+		if ep.re != nil {
+			if ep.re.MatchString(name) {
+				return true
+			}
+			continue
+		}
+		if ep.name == name {
+			return true
+		}
+		if ok, err := path.Match(ep.name, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegexp(p string) bool {
+	return len(p) >= 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/")
+}
+
+// MatchPackage reports whether the import path matches the package pattern.
+// The pattern matches the import path that is equal to it, ends with it (after a slash),
+// or whose trailing elements match it as a glob pattern (e.g. */internal/testutil).
+func MatchPackage(pattern, pkgPath string) bool {
+	elems := strings.Split(pkgPath, "/")
+	for i := range elems {
+		ok, err := doublestar.Match(pattern, strings.Join(elems[i:], "/"))
+		if err != nil {
+			return false
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package detector
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExcludes(t *testing.T) {
+	e, err := NewExcludes([]string{
+		"",
+		"Foo",
+		"Get*",
+		"/^Test.*_/",
+		"pkg/api:GetHTTPStatus",
+		"*/gen/*:/^XXX_/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pkgPath string
+		name    string
+		want    bool
+	}{
+		{"example.com/a", "Foo", true},
+		{"example.com/a", "FooBar", false},
+		{"example.com/a", "GetName", true},
+		{"example.com/a", "Test_Foo", true},
+		{"example.com/a", "TestFoo", false},
+		{"example.com/a", "", false},
+		{"example.com/pkg/api", "GetHTTPStatus", true},
+		{"pkg/api", "GetHTTPStatus", true},
+		{"example.com/pkg/apis", "GetHTTPStatus", true}, // matched by Get*
+		{"example.com/a", "SetHTTPStatus", false},
+		{"example.com/gen/pb", "XXX_unrecognized", true},
+		{"example.com/pb", "XXX_unrecognized", false},
+	}
+	for _, tt := range tests {
+		if got := e.Match(tt.pkgPath, tt.name); got != tt.want {
+			t.Errorf("%s:%s: got %v want %v", tt.pkgPath, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewExcludesInvalid(t *testing.T) {
+	for _, p := range []string{"/[/", "Get[", "pkg[:Get"} {
+		if _, err := NewExcludes([]string{p}); err == nil {
+			t.Errorf("%s: want error", p)
+		}
+	}
+}
+
+func TestSplitExcludes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{""}},
+		{"Foo,Get*", []string{"Foo", "Get*"}},
+		{"/^A{1,3}$/,Foo", []string{"/^A{1,3}$/", "Foo"}},
+		{"pkg/api:/^A{1,3}$/,Foo", []string{"pkg/api:/^A{1,3}$/", "Foo"}},
+		{"/^(a|b),c/", []string{"/^(a|b),c/"}},
+		{"/^A{1,", []string{"/^A{1,"}},
+	}
+	for _, tt := range tests {
+		if got := SplitExcludes(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %q want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPackage(t *testing.T) {
+	tests := []struct {
+		pattern string
+		pkgPath string
+		want    bool
+	}{
+		{"pkg/api", "pkg/api", true},
+		{"pkg/api", "example.com/pkg/api", true},
+		{"pkg/api", "example.com/pkg/apis", false},
+		{"api", "example.com/myapi", false},
+		{"*/gen/*", "example.com/gen/pb", true},
+		{"*/gen/*", "example.com/x/gen/pb", true},
+		{"*/gen/*", "example.com/gen/pb/v1", false},
+		{"**/gen/**", "example.com/gen/pb/v1", true},
+		{"*/internal/testutil", "github.com/a/b/internal/testutil", true},
+		{"pkg[", "pkg/api", false},
+	}
+	for _, tt := range tests {
+		if got := MatchPackage(tt.pattern, tt.pkgPath); got != tt.want {
+			t.Errorf("%s %s: got %v want %v", tt.pattern, tt.pkgPath, got, tt.want)
+		}
+	}
+}
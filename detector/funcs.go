@@ -0,0 +1,70 @@
+package detector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Funcs is the list of the functions with the index of the argument to check.
+//
+// A function is written as the package path, a dot and the function name, optionally followed by a colon and the index of the argument
+// (e.g. github.com/pkg/errors.Wrapf:1). If the index is omitted, Arg returns ArgOmitted and the caller decides the argument.
+// The package path matches the import path that is equal to it or ends with it (after a slash) (e.g. xerrors.Errorf).
+type Funcs struct {
+	funcs []funcArg
+}
+
+// ArgOmitted is the index of the argument of the function written without the index.
+const ArgOmitted = -1
+
+type funcArg struct {
+	pkg  string
+	name string
+	arg  int
+}
+
+// NewFuncs parses the functions. Empty entries are ignored.
+func NewFuncs(funcs []string) (*Funcs, error) {
+	fs := &Funcs{}
+	for _, f := range funcs {
+		if f == "" {
+			continue
+		}
+		fa, err := parseFunc(f)
+		if err != nil {
+			return nil, err
+		}
+		fs.funcs = append(fs.funcs, fa)
+	}
+	return fs, nil
+}
+
+func parseFunc(f string) (funcArg, error) {
+	fa := funcArg{arg: ArgOmitted}
+	fn := f
+	if i := strings.LastIndex(fn, ":"); i >= 0 {
+		n, err := strconv.Atoi(fn[i+1:])
+		if err != nil || n < 0 {
+			return fa, fmt.Errorf("invalid function %q: the index of the argument must be a non-negative integer", f)
+		}
+		fa.arg = n
+		fn = fn[:i]
+	}
+	i := strings.LastIndex(fn, ".")
+	if i <= strings.LastIndex(fn, "/") || i == len(fn)-1 {
+		return fa, fmt.Errorf("invalid function %q: must be the package path and the function name (e.g. github.com/pkg/errors.Wrapf)", f)
+	}
+	fa.pkg, fa.name = fn[:i], fn[i+1:]
+	return fa, nil
+}
+
+// Arg returns the index of the argument to check of the function in the package (import path), and whether the function is in the list.
+func (fs *Funcs) Arg(pkgPath, name string) (int, bool) {
+	for _, fa := range fs.funcs {
+		if fa.name == name && (fa.pkg == pkgPath || strings.HasSuffix(pkgPath, "/"+fa.pkg)) {
+			return fa.arg, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,42 @@
+package detector
+
+import "testing"
+
+func TestFuncs(t *testing.T) {
+	fs, err := NewFuncs([]string{
+		"",
+		"fmt.Errorf",
+		"github.com/pkg/errors.Wrapf:1",
+		"xerrors.Errorf",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pkgPath string
+		name    string
+		want    int
+		wantOK  bool
+	}{
+		{"fmt", "Errorf", ArgOmitted, true},
+		{"fmt", "Sprintf", 0, false},
+		{"github.com/pkg/errors", "Wrapf", 1, true},
+		{"github.com/pkg/errors", "Errorf", 0, false},
+		{"golang.org/x/xerrors", "Errorf", ArgOmitted, true},
+		{"example.com/myxerrors", "Errorf", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := fs.Arg(tt.pkgPath, tt.name)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s.%s: got %v, %v want %v, %v", tt.pkgPath, tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestNewFuncsInvalid(t *testing.T) {
+	for _, f := range []string{"Errorf", "github.com/pkg/errors", "fmt.", "fmt.Errorf:x", "fmt.Errorf:-1"} {
+		if _, err := NewFuncs([]string{f}); err == nil {
+			t.Errorf("%s: want error", f)
+		}
+	}
+}
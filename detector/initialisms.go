@@ -1,5 +1,53 @@
 package detector
 
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// DefaultInitialisms is the initialisms table with the default initialisms.
+var DefaultInitialisms = NewInitialisms(nil, nil)
+
+// Initialisms is the table of initialisms (e.g. ID, URL) that are written in the same case in MixedCaps names.
+type Initialisms struct {
+	// upper is the initialisms keyed by the uppercase ones.
+	upper map[string]string
+	// keys is the initialisms sorted longest first.
+	keys []string
+}
+
+// NewInitialisms returns the initialisms table with the default initialisms, the added initialisms and without the removed initialisms.
+// The removed initialisms are matched case-insensitively.
+func NewInitialisms(add, remove []string) *Initialisms {
+	in := &Initialisms{upper: map[string]string{}}
+	for k := range initialisms {
+		in.upper[strings.ToUpper(k)] = k
+	}
+	for _, k := range add {
+		if k != "" {
+			in.upper[strings.ToUpper(k)] = k
+		}
+	}
+	for _, k := range remove {
+		delete(in.upper, strings.ToUpper(k))
+	}
+	for _, k := range in.upper {
+		in.keys = append(in.keys, k)
+	}
+	// The longer initialisms are matched first (e.g. UUID before ID).
+	slices.SortFunc(in.keys, func(a, b string) int {
+		return cmp.Or(len(b)-len(a), strings.Compare(a, b))
+	})
+	return in
+}
+
+// Lookup returns the initialism in the table that is case-insensitively equal to s.
+func (in *Initialisms) Lookup(s string) (string, bool) {
+	k, ok := in.upper[strings.ToUpper(s)]
+	return k, ok
+}
+
 var initialisms = map[string]struct{}{
 	"ACL":    {},
 	"API":    {},
@@ -12,6 +60,8 @@ var initialisms = map[string]struct{}{
 	"EOF":    {},
 	"GUID":   {},
 	"HTML":   {},
+	"HTTP":   {},
+	"HTTPS":  {},
 	"ID":     {},
 	"MD5":    {},
 	"NS":     {},
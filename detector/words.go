@@ -0,0 +1,131 @@
+package detector
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitWords splits the name into words with the default initialisms.
+func SplitWords(s string) []string {
+	return DefaultInitialisms.SplitWords(s)
+}
+
+// SplitWords splits the name into words.
+// The words are separated at the case changes (e.g. fooBar -> foo, Bar) and underscores, and
+//   - a run of uppercase letters is split into the initialisms in it (e.g. XMLHTTPRequest -> XML, HTTP, Request),
+//     or kept as one word if it is not made of initialisms (e.g. MAXLength -> MAX, Length),
+//   - initialisms written in mixed case are kept as one word (e.g. OAuthToken -> OAuth, Token),
+//   - the plural of initialisms is kept as one word (e.g. userIDs -> user, IDs),
+//   - digits belong to the preceding word (e.g. SHA256Sum -> SHA256, Sum).
+//
+// The leading underscores and the type parameters (e.g. List[T]) are ignored.
+func (in *Initialisms) SplitWords(s string) []string {
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		s = s[:i]
+	}
+	var words []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_'
+	}) {
+		words = append(words, in.splitCamel([]rune(part))...)
+	}
+	return words
+}
+
+func (in *Initialisms) splitCamel(r []rune) []string {
+	var words []string
+	for p := 0; p < len(r); {
+		if k, ok := in.mixedCaseAt(r[p:]); ok {
+			q := skipDigits(r, p+len([]rune(k)))
+			words = append(words, string(r[p:q]))
+			p = q
+			continue
+		}
+		if !unicode.IsUpper(r[p]) || (p+1 < len(r) && unicode.IsLower(r[p+1])) {
+			// A lowercase word or a capitalized word.
+			q := skipDigits(r, skipLower(r, p+1))
+			words = append(words, string(r[p:q]))
+			p = q
+			continue
+		}
+		q := p
+		for q < len(r) && (unicode.IsUpper(r[q]) || unicode.IsDigit(r[q])) {
+			q++
+		}
+		switch {
+		case q == len(r) || unicode.IsDigit(r[q-1]):
+		case r[q] == 's' && (q+1 == len(r) || !unicode.IsLower(r[q+1])):
+			if ws, ok := in.decompose(string(r[p:q])); ok {
+				// The plural of initialisms (e.g. IDs).
+				ws[len(ws)-1] += "s"
+				words = append(words, ws...)
+				p = q + 1
+				continue
+			}
+			q--
+		default:
+			// The last uppercase letter begins the next word (e.g. HTTPServer -> HTTP, Server).
+			q--
+		}
+		if ws, ok := in.decompose(string(r[p:q])); ok {
+			words = append(words, ws...)
+		} else {
+			words = append(words, string(r[p:q]))
+		}
+		p = q
+	}
+	return words
+}
+
+// mixedCaseAt returns the initialism written in mixed case (e.g. OAuth, iOS) at the beginning of r.
+func (in *Initialisms) mixedCaseAt(r []rune) (string, bool) {
+	s := string(r)
+	for _, k := range in.keys {
+		if strings.ToUpper(k) == k || !strings.HasPrefix(s, k) {
+			continue
+		}
+		rest := []rune(s[len(k):])
+		if len(rest) == 0 || !unicode.IsLower(rest[0]) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// decompose splits the run of uppercase letters and digits into initialisms.
+// The digits following an initialism belong to it (e.g. HTTP2).
+func (in *Initialisms) decompose(s string) ([]string, bool) {
+	var words []string
+	r := []rune(s)
+	for p := 0; p < len(r); {
+		var n int
+		for _, k := range in.keys {
+			kr := []rune(k)
+			if len(kr) <= len(r)-p && strings.EqualFold(string(r[p:p+len(kr)]), k) {
+				n = len(kr)
+				break
+			}
+		}
+		if n == 0 {
+			return nil, false
+		}
+		q := skipDigits(r, p+n)
+		words = append(words, string(r[p:q]))
+		p = q
+	}
+	return words, true
+}
+
+func skipLower(r []rune, p int) int {
+	for p < len(r) && !unicode.IsUpper(r[p]) && !unicode.IsDigit(r[p]) {
+		p++
+	}
+	return p
+}
+
+func skipDigits(r []rune, p int) int {
+	for p < len(r) && unicode.IsDigit(r[p]) {
+		p++
+	}
+	return p
+}
@@ -0,0 +1,72 @@
+package detector
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"fooBar", []string{"foo", "Bar"}},
+		{"FooBar", []string{"Foo", "Bar"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"HTTPSServer", []string{"HTTPS", "Server"}},
+		{"XMLHTTPRequest", []string{"XML", "HTTP", "Request"}},
+		{"xmlHttpRequest", []string{"xml", "Http", "Request"}},
+		{"ServeHTTP", []string{"Serve", "HTTP"}},
+		{"userID", []string{"user", "ID"}},
+		{"userIDs", []string{"user", "IDs"}},
+		{"URLsFor", []string{"URLs", "For"}},
+		{"MAXLENGTH", []string{"MAXLENGTH"}},
+		{"MAXLength", []string{"MAX", "Length"}},
+		{"IDLE", []string{"IDLE"}},
+		{"SHA256Sum", []string{"SHA256", "Sum"}},
+		{"sha256Sum", []string{"sha256", "Sum"}},
+		{"HTTP2Server", []string{"HTTP2", "Server"}},
+		{"Int64Value", []string{"Int64", "Value"}},
+		{"V2beta", []string{"V2", "beta"}},
+		{"OAuthToken", []string{"OAuth", "Token"}},
+		{"newOAuthToken", []string{"new", "OAuth", "Token"}},
+		{"iOSVersion", []string{"iOS", "Version"}},
+		{"DDoSAttack", []string{"DDoS", "Attack"}},
+		{"_fooBar", []string{"foo", "Bar"}},
+		{"__foo", []string{"foo"}},
+		{"MAX_LENGTH", []string{"MAX", "LENGTH"}},
+		{"user_id", []string{"user", "id"}},
+		{"List[T]", []string{"List"}},
+		{"Map[K, V]", []string{"Map"}},
+		{"Getaway", []string{"Getaway"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := SplitWords(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitWordsWithInitialisms(t *testing.T) {
+	in := NewInitialisms([]string{"GRPC", "K8S"}, []string{"HTTP"})
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"GRPCServer", []string{"GRPC", "Server"}},
+		{"K8SClient", []string{"K8S", "Client"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"XMLHTTPRequest", []string{"XMLHTTP", "Request"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := in.SplitWords(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
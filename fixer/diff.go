@@ -0,0 +1,160 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const contextLines = 3
+
+type op struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Diff returns the unified diff between before and after of the file name.
+// It returns an empty string if there is no difference.
+func Diff(name string, before, after []byte) string {
+	if string(before) == string(after) {
+		return ""
+	}
+	ops := diffLines(splitLines(string(before)), splitLines(string(after)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n", name)
+	fmt.Fprintf(&b, "+++ b/%s\n", name)
+	i := 0
+	for i < len(ops) {
+		// find the next change
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		start := max(i-contextLines, 0)
+		// extend the hunk while changes are close enough
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+				continue
+			}
+			if j-end >= contextLines*2 {
+				break
+			}
+		}
+		end = min(end+contextLines, len(ops))
+		writeHunk(&b, ops, start, end)
+		i = end
+	}
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, ops []op, start, end int) {
+	// line numbers of the first line of the hunk
+	al, bl := 1, 1
+	for _, o := range ops[:start] {
+		if o.kind != '+' {
+			al++
+		}
+		if o.kind != '-' {
+			bl++
+		}
+	}
+	var an, bn int
+	for _, o := range ops[start:end] {
+		if o.kind != '+' {
+			an++
+		}
+		if o.kind != '-' {
+			bn++
+		}
+	}
+	if an == 0 {
+		al--
+	}
+	if bn == 0 {
+		bl--
+	}
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", al, an, bl, bn)
+	for _, o := range ops[start:end] {
+		b.WriteByte(o.kind)
+		b.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script between a and b using the Myers diff algorithm.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+L:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break L
+			}
+		}
+	}
+
+	// backtrack
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var pk int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := v[offset+pk]
+		py := px - pk
+		for x > px && y > py {
+			ops = append(ops, op{kind: ' ', line: a[x-1]})
+			x--
+			y--
+		}
+		if d == 0 {
+			break
+		}
+		if x == px {
+			ops = append(ops, op{kind: '+', line: b[y-1]})
+			y--
+		} else {
+			ops = append(ops, op{kind: '-', line: a[x-1]})
+			x--
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
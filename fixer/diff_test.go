@@ -0,0 +1,50 @@
+package fixer
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			"no change",
+			"a\nb\n",
+			"a\nb\n",
+			"",
+		},
+		{
+			"change",
+			"a\nb\nc\n",
+			"a\nB\nc\n",
+			"--- a/a.go\n+++ b/a.go\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			"separated hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			"--- a/a.go\n+++ b/a.go\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			"insertion",
+			"a\n",
+			"a\nb\n",
+			"--- a/a.go\n+++ b/a.go\n@@ -1,1 +1,2 @@\n a\n+b\n",
+		},
+		{
+			"no newline at end of file",
+			"a",
+			"b",
+			"--- a/a.go\n+++ b/a.go\n@@ -1,1 +1,1 @@\n-a\n\\ No newline at end of file\n+b\n\\ No newline at end of file\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff("a.go", []byte(tt.before), []byte(tt.after)); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
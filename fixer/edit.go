@@ -0,0 +1,100 @@
+package fixer
+
+import (
+	"go/token"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Edit is a replacement of the byte range [Start, End) of a file with New.
+type Edit struct {
+	Start int
+	End   int
+	New   string
+}
+
+// Resolve converts suggested fixes into edits per file name.
+// A fix whose edits conflict with the edits of a preceding fix is skipped as a whole, so that no fix is applied partially.
+// Identical edits are coalesced.
+// It returns the edits sorted by position and the number of skipped fixes.
+func Resolve(fset *token.FileSet, fixes []analysis.SuggestedFix) (map[string][]Edit, int) {
+	edits := map[string][]Edit{}
+	skipped := 0
+L:
+	for _, fix := range fixes {
+		fe := map[string][]Edit{}
+		for _, te := range fix.TextEdits {
+			f := fset.File(te.Pos)
+			if f == nil {
+				continue L
+			}
+			end := te.End
+			if !end.IsValid() {
+				end = te.Pos
+			}
+			e := Edit{
+				Start: f.Offset(te.Pos),
+				End:   f.Offset(end),
+				New:   string(te.NewText),
+			}
+			for _, a := range edits[f.Name()] {
+				if conflict(a, e) {
+					skipped++
+					continue L
+				}
+			}
+			fe[f.Name()] = append(fe[f.Name()], e)
+		}
+		for n, es := range fe {
+			for _, e := range es {
+				if contains(edits[n], e) {
+					continue
+				}
+				edits[n] = append(edits[n], e)
+			}
+		}
+	}
+	for _, es := range edits {
+		sort.Slice(es, func(i, j int) bool {
+			if es[i].Start != es[j].Start {
+				return es[i].Start < es[j].Start
+			}
+			return es[i].End < es[j].End
+		})
+	}
+	return edits, skipped
+}
+
+// Apply applies edits sorted by position to src.
+func Apply(src []byte, edits []Edit) []byte {
+	var out []byte
+	last := 0
+	for _, e := range edits {
+		out = append(out, src[last:e.Start]...)
+		out = append(out, e.New...)
+		last = e.End
+	}
+	out = append(out, src[last:]...)
+	return out
+}
+
+func conflict(a, b Edit) bool {
+	if a == b {
+		return false
+	}
+	if a.Start == b.Start {
+		// two different edits at the same position (including insertions)
+		return true
+	}
+	return a.Start < b.End && b.Start < a.End
+}
+
+func contains(edits []Edit, e Edit) bool {
+	for _, ee := range edits {
+		if ee == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package fixer
+
+import (
+	"go/token"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestResolveAndApply(t *testing.T) {
+	src := []byte("var foo_bar = 1\nvar x = foo_bar\n")
+	fset := token.NewFileSet()
+	f := fset.AddFile("a.go", -1, len(src))
+	f.SetLinesForContent(src)
+	edit := func(start, end int, s string) analysis.TextEdit {
+		return analysis.TextEdit{Pos: f.Pos(start), End: f.Pos(end), NewText: []byte(s)}
+	}
+	tests := []struct {
+		name        string
+		fixes       []analysis.SuggestedFix
+		want        string
+		wantSkipped int
+	}{
+		{
+			"rename",
+			[]analysis.SuggestedFix{
+				{TextEdits: []analysis.TextEdit{edit(4, 11, "fooBar"), edit(24, 31, "fooBar")}},
+			},
+			"var fooBar = 1\nvar x = fooBar\n",
+			0,
+		},
+		{
+			"identical edits are coalesced",
+			[]analysis.SuggestedFix{
+				{TextEdits: []analysis.TextEdit{edit(4, 11, "fooBar"), edit(24, 31, "fooBar")}},
+				{TextEdits: []analysis.TextEdit{edit(24, 31, "fooBar")}},
+			},
+			"var fooBar = 1\nvar x = fooBar\n",
+			0,
+		},
+		{
+			"conflicting fix is skipped as a whole",
+			[]analysis.SuggestedFix{
+				{TextEdits: []analysis.TextEdit{edit(4, 11, "fooBar"), edit(24, 31, "fooBar")}},
+				{TextEdits: []analysis.TextEdit{edit(20, 21, "y"), edit(24, 31, "FooBar")}},
+			},
+			"var fooBar = 1\nvar x = fooBar\n",
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, skipped := Resolve(fset, tt.fixes)
+			if skipped != tt.wantSkipped {
+				t.Errorf("got %v want %v", skipped, tt.wantSkipped)
+			}
+			if got := string(Apply(src, edits["a.go"])); got != tt.want {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
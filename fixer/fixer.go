@@ -0,0 +1,106 @@
+package fixer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Rename returns suggested fixes that rename the object defined by id to `to` everywhere it is used in the package.
+// It returns nil if the object cannot be renamed safely (e.g. methods, struct fields, exported package-level objects or a name conflict).
+// Exported package-level objects may be used by other packages (including the external test package) that the pass cannot see,
+// so they are renamed only in external test packages, which cannot be imported.
+func Rename(pass *analysis.Pass, id *ast.Ident, to string) []analysis.SuggestedFix {
+	if to == "" || to == id.Name {
+		return nil
+	}
+	obj := pass.TypesInfo.Defs[id]
+	if obj == nil {
+		return nil
+	}
+	switch o := obj.(type) {
+	case *types.Var:
+		if o.IsField() || o.Embedded() {
+			return nil
+		}
+	case *types.Func:
+		// Renaming methods may break interface implementations.
+		if sig, ok := o.Type().(*types.Signature); ok && sig.Recv() != nil {
+			return nil
+		}
+	case *types.TypeName:
+		// Renaming embedded types changes the field names.
+		for _, d := range pass.TypesInfo.Defs {
+			v, ok := d.(*types.Var)
+			if !ok || !v.Embedded() {
+				continue
+			}
+			if n, ok := types.Unalias(deref(v.Type())).(*types.Named); ok && n.Obj() == o {
+				return nil
+			}
+		}
+	}
+	if obj.Parent() == nil {
+		return nil
+	}
+	if obj.Exported() && obj.Parent() == pass.Pkg.Scope() && !strings.HasSuffix(pass.Pkg.Path(), "_test") {
+		return nil
+	}
+	if obj.Parent().Lookup(to) != nil {
+		// name conflict in the same scope
+		return nil
+	}
+	if _, o := obj.Parent().LookupParent(to, obj.Pos()); o != nil {
+		// name conflict
+		return nil
+	}
+
+	var edits []analysis.TextEdit
+	for _, m := range []map[*ast.Ident]types.Object{pass.TypesInfo.Defs, pass.TypesInfo.Uses} {
+		for i, o := range m {
+			if o != obj {
+				continue
+			}
+			if shadowed(pass, i.Pos(), to) {
+				// The renamed reference would refer to the other object declared in the nested scope.
+				return nil
+			}
+			edits = append(edits, analysis.TextEdit{
+				Pos:     i.Pos(),
+				End:     i.End(),
+				NewText: []byte(to),
+			})
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Pos < edits[j].Pos
+	})
+	return []analysis.SuggestedFix{
+		{
+			Message:   fmt.Sprintf("Rename %s to %s", id.Name, to),
+			TextEdits: edits,
+		},
+	}
+}
+
+// shadowed reports whether an object named `to` is visible at pos.
+func shadowed(pass *analysis.Pass, pos token.Pos, to string) bool {
+	sc := pass.Pkg.Scope().Innermost(pos)
+	if sc == nil {
+		return false
+	}
+	_, o := sc.LookupParent(to, pos)
+	return o != nil
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
@@ -0,0 +1,65 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/k1LoW/gostyle/runner"
+	"github.com/k1LoW/gostyle/version"
+)
+
+const checkstyleVersion = "5.0"
+
+type checkstyleOutput struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func writeCheckstyle(w io.Writer, diags []*runner.Diagnostic) error {
+	out := &checkstyleOutput{Version: checkstyleVersion}
+	files := map[string]*checkstyleFile{}
+	for _, d := range diags {
+		n := relPath(d.Posn.Filename)
+		f, ok := files[n]
+		if !ok {
+			f = &checkstyleFile{Name: n}
+			files[n] = f
+			out.Files = append(out.Files, f)
+		}
+		f.Errors = append(f.Errors, &checkstyleError{
+			Line:     d.Posn.Line,
+			Column:   d.Posn.Column,
+			Severity: d.Severity,
+			Message:  d.Message,
+			Source:   version.Name + "." + d.Analyzer.Name,
+		})
+	}
+	return writeXML(w, out)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,73 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/runner"
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	Text       = "text"
+	JSON       = "json"
+	SARIF      = "sarif"
+	Checkstyle = "checkstyle"
+	JUnit      = "junit"
+)
+
+// Formats is the list of available output formats.
+var Formats = []string{
+	Text,
+	JSON,
+	SARIF,
+	Checkstyle,
+	JUnit,
+}
+
+// Write writes diagnostics to w in the format.
+func Write(w io.Writer, format string, analyzers []*analysis.Analyzer, diags []*runner.Diagnostic) error {
+	switch format {
+	case Text, "":
+		return writeText(w, diags)
+	case JSON:
+		return writeJSON(w, diags)
+	case SARIF:
+		return writeSARIF(w, analyzers, diags)
+	case Checkstyle:
+		return writeCheckstyle(w, diags)
+	case JUnit:
+		return writeJUnit(w, diags)
+	default:
+		return fmt.Errorf("unsupported format: %s (available: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// title returns the first paragraph of the doc of the analyzer.
+func title(a *analysis.Analyzer) string {
+	return strings.Split(a.Doc, "\n\n")[0]
+}
+
+// referenceURL returns the URL of the style that the analyzer is based on.
+func referenceURL(a *analysis.Analyzer) string {
+	if m, ok := meta.Of(a.Name); ok && m.URL != "" {
+		return m.URL
+	}
+	return a.URL
+}
+
+func relPath(p string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return p
+	}
+	rel, err := filepath.Rel(wd, p)
+	if err != nil {
+		return p
+	}
+	return filepath.ToSlash(rel)
+}
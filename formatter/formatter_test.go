@@ -0,0 +1,163 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/gostyle/meta"
+	"github.com/k1LoW/gostyle/reporter"
+	"github.com/k1LoW/gostyle/runner"
+	"golang.org/x/tools/go/analysis"
+)
+
+func testDiagnostics(t *testing.T) ([]*analysis.Analyzer, []*runner.Diagnostic) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.Register(meta.Meta{Name: "mixedcaps", Source: meta.SourceGuide, URL: "https://google.github.io/styleguide/go/guide#mixed-caps"})
+	a := &analysis.Analyzer{
+		Name: "mixedcaps",
+		Doc:  "Analyzer based on https://google.github.io/styleguide/go/guide#mixed-caps.",
+	}
+	diags := []*runner.Diagnostic{
+		{
+			Diagnostic: analysis.Diagnostic{Message: "[gostyle.mixedcaps] message: MAX_LENGTH"},
+			Analyzer:   a,
+			Posn:       token.Position{Filename: filepath.Join(wd, "a.go"), Line: 3, Column: 7},
+			Severity:   reporter.SeverityError,
+		},
+		{
+			Diagnostic: analysis.Diagnostic{Message: "[gostyle.mixedcaps] message: go_Pher"},
+			Analyzer:   a,
+			Posn:       token.Position{Filename: filepath.Join(wd, "a.go"), Line: 6, Column: 2},
+			Severity:   reporter.SeverityWarning,
+		},
+	}
+	return []*analysis.Analyzer{a}, diags
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			JSON,
+			`[
+  {
+    "analyzer": "mixedcaps",
+    "file": "a.go",
+    "line": 3,
+    "column": 7,
+    "severity": "error",
+    "message": "[gostyle.mixedcaps] message: MAX_LENGTH"
+  },
+  {
+    "analyzer": "mixedcaps",
+    "file": "a.go",
+    "line": 6,
+    "column": 2,
+    "severity": "warning",
+    "message": "[gostyle.mixedcaps] message: go_Pher"
+  }
+]
+`,
+		},
+		{
+			Checkstyle,
+			`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="a.go">
+    <error line="3" column="7" severity="error" message="[gostyle.mixedcaps] message: MAX_LENGTH" source="gostyle.mixedcaps"></error>
+    <error line="6" column="2" severity="warning" message="[gostyle.mixedcaps] message: go_Pher" source="gostyle.mixedcaps"></error>
+  </file>
+</checkstyle>
+`,
+		},
+		{
+			JUnit,
+			`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="a.go" tests="2" failures="2">
+    <testcase name="mixedcaps" classname="a.go:3:7">
+      <failure message="[gostyle.mixedcaps] message: MAX_LENGTH" type="error">a.go:3:7: [gostyle.mixedcaps] message: MAX_LENGTH</failure>
+    </testcase>
+    <testcase name="mixedcaps" classname="a.go:6:2">
+      <failure message="[gostyle.mixedcaps] message: go_Pher" type="warning">a.go:6:2: [gostyle.mixedcaps] message: go_Pher</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+	}
+	analyzers, diags := testDiagnostics(t)
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := Write(buf, tt.format, analyzers, diags); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	analyzers, diags := testDiagnostics(t)
+	buf := new(bytes.Buffer)
+	if err := Write(buf, Text, analyzers, diags); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("%s: [gostyle.mixedcaps] message: MAX_LENGTH\n%s: warning: [gostyle.mixedcaps] message: go_Pher\n", diags[0].Posn, diags[1].Posn)
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	analyzers, diags := testDiagnostics(t)
+	buf := new(bytes.Buffer)
+	if err := Write(buf, SARIF, analyzers, diags); err != nil {
+		t.Fatal(err)
+	}
+	var got sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != sarifVersion {
+		t.Errorf("got %v want %v", got.Version, sarifVersion)
+	}
+	rules := got.Runs[0].Tool.Driver.Rules
+	if len(rules) != 1 {
+		t.Fatalf("got %v want %v", len(rules), 1)
+	}
+	if want := "https://google.github.io/styleguide/go/guide#mixed-caps"; rules[0].HelpURI != want {
+		t.Errorf("got %v want %v", rules[0].HelpURI, want)
+	}
+	results := got.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("got %v want %v", len(results), 2)
+	}
+	if want := "warning"; results[1].Level != want {
+		t.Errorf("got %v want %v", results[1].Level, want)
+	}
+	if want := "a.go"; results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != want {
+		t.Errorf("got %v want %v", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, want)
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	analyzers, diags := testDiagnostics(t)
+	if err := Write(new(bytes.Buffer), "unknown", analyzers, diags); err == nil {
+		t.Error("want error")
+	}
+}
@@ -0,0 +1,34 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/k1LoW/gostyle/runner"
+)
+
+type jsonDiagnostic struct {
+	Analyzer string `json:"analyzer"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func writeJSON(w io.Writer, diags []*runner.Diagnostic) error {
+	out := make([]jsonDiagnostic, 0, len(diags))
+	for _, d := range diags {
+		out = append(out, jsonDiagnostic{
+			Analyzer: d.Analyzer.Name,
+			File:     relPath(d.Posn.Filename),
+			Line:     d.Posn.Line,
+			Column:   d.Posn.Column,
+			Severity: d.Severity,
+			Message:  d.Message,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
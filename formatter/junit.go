@@ -0,0 +1,60 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/k1LoW/gostyle/runner"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name          `xml:"testsuites"`
+	Suites  []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, diags []*runner.Diagnostic) error {
+	out := &junitTestSuites{}
+	suites := map[string]*junitTestSuite{}
+	for _, d := range diags {
+		n := relPath(d.Posn.Filename)
+		s, ok := suites[n]
+		if !ok {
+			s = &junitTestSuite{Name: n}
+			suites[n] = s
+			out.Suites = append(out.Suites, s)
+		}
+		posn := fmt.Sprintf("%s:%d:%d", n, d.Posn.Line, d.Posn.Column)
+		s.Tests++
+		s.Failures++
+		s.TestCases = append(s.TestCases, &junitTestCase{
+			Name:      d.Analyzer.Name,
+			ClassName: posn,
+			Failure: &junitFailure{
+				Message: d.Message,
+				Type:    d.Severity,
+				Content: fmt.Sprintf("%s: %s", posn, d.Message),
+			},
+		})
+	}
+	return writeXML(w, out)
+}
@@ -0,0 +1,145 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/k1LoW/gostyle/reporter"
+	"github.com/k1LoW/gostyle/runner"
+	"github.com/k1LoW/gostyle/version"
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolURI      = "https://github.com/k1LoW/gostyle"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeSARIF(w io.Writer, analyzers []*analysis.Analyzer, diags []*runner.Diagnostic) error {
+	driver := sarifDriver{
+		Name:           version.Name,
+		Version:        version.Version,
+		InformationURI: toolURI,
+		Rules:          []sarifRule{},
+	}
+	idx := map[string]int{}
+	for _, a := range analyzers {
+		if a.Name == version.Name {
+			// config loader
+			continue
+		}
+		idx[a.Name] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               a.Name,
+			Name:             a.Name,
+			ShortDescription: sarifMessage{Text: title(a)},
+			FullDescription:  sarifMessage{Text: a.Doc},
+			HelpURI:          referenceURL(a),
+		})
+	}
+	results := make([]sarifResult, 0, len(diags))
+	for _, d := range diags {
+		results = append(results, sarifResult{
+			RuleID:    d.Analyzer.Name,
+			RuleIndex: idx[d.Analyzer.Name],
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: relPath(d.Posn.Filename)},
+						Region: sarifRegion{
+							StartLine:   d.Posn.Line,
+							StartColumn: d.Posn.Column,
+						},
+					},
+				},
+			},
+		})
+	}
+	l := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case reporter.SeverityWarning:
+		return "warning"
+	case reporter.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,24 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/k1LoW/gostyle/reporter"
+	"github.com/k1LoW/gostyle/runner"
+)
+
+func writeText(w io.Writer, diags []*runner.Diagnostic) error {
+	for _, d := range diags {
+		if d.Severity != reporter.SeverityError {
+			if _, err := fmt.Fprintf(w, "%s: %s: %s\n", d.Posn, d.Severity, d.Message); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", d.Posn, d.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
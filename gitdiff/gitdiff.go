@@ -0,0 +1,188 @@
+package gitdiff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// Changes is a set of lines changed in files.
+type Changes struct {
+	// lines is the set of added or modified lines per file (absolute path).
+	lines map[string]map[int]struct{}
+	// all is the set of files whose lines are all changed (e.g. untracked files).
+	all map[string]struct{}
+}
+
+// Parse parses the unified diff. The file paths in the diff are resolved relative to root.
+func Parse(r io.Reader, root string) (*Changes, error) {
+	c := &Changes{
+		lines: map[string]map[int]struct{}{},
+		all:   map[string]struct{}{},
+	}
+	var (
+		current map[int]struct{}
+		line    int
+		remain  int
+	)
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for s.Scan() {
+		t := s.Text()
+		switch {
+		case remain > 0 && (strings.HasPrefix(t, "+") || strings.HasPrefix(t, " ") || t == ""):
+			if strings.HasPrefix(t, "+") {
+				current[line] = struct{}{}
+			}
+			line++
+			remain--
+		case remain > 0 && strings.HasPrefix(t, "-"):
+			// deleted line
+		case strings.HasPrefix(t, "+++ "):
+			name := strings.TrimPrefix(t, "+++ ")
+			if i := strings.Index(name, "\t"); i >= 0 {
+				// timestamp
+				name = name[:i]
+			}
+			if name == "/dev/null" {
+				// deleted file
+				current = map[int]struct{}{}
+				continue
+			}
+			name = strings.TrimPrefix(name, "b/")
+			p := filepath.Join(root, filepath.FromSlash(name))
+			if _, ok := c.lines[p]; !ok {
+				c.lines[p] = map[int]struct{}{}
+			}
+			current = c.lines[p]
+		case strings.HasPrefix(t, "@@ "):
+			if current == nil {
+				return nil, fmt.Errorf("invalid diff: hunk without file header: %s", t)
+			}
+			m := hunkRe.FindStringSubmatch(t)
+			if len(m) == 0 {
+				return nil, fmt.Errorf("invalid diff: invalid hunk header: %s", t)
+			}
+			var err error
+			line, err = strconv.Atoi(m[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid diff: invalid hunk header: %s: %w", t, err)
+			}
+			remain = 1
+			if m[2] != "" {
+				remain, err = strconv.Atoi(m[2])
+				if err != nil {
+					return nil, fmt.Errorf("invalid diff: invalid hunk header: %s: %w", t, err)
+				}
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// FromPatch returns the changes in the patch file.
+// The file paths in the patch are resolved relative to the root of the git repository (or the current directory).
+func FromPatch(p string) (*Changes, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open patch file: %w", err)
+	}
+	defer f.Close()
+	root, err := gitRoot()
+	if err != nil {
+		root, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return Parse(f, root)
+}
+
+// FromRev returns the changes of the working tree (including untracked files) since the git revision.
+func FromRev(rev string) (*Changes, error) {
+	root, err := gitRoot()
+	if err != nil {
+		return nil, err
+	}
+	out, err := git(root, "diff", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/", "-U0", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	c, err := Parse(bytes.NewReader(out), root)
+	if err != nil {
+		return nil, err
+	}
+	out, err = git(root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		c.all[filepath.Join(root, filepath.FromSlash(name))] = struct{}{}
+	}
+	return c, nil
+}
+
+// Contains reports whether any of the lines from start to end of the file is changed.
+func (c *Changes) Contains(filename string, start, end int) bool {
+	if _, ok := c.lines[filename]; !ok {
+		if _, ok := c.all[filename]; !ok {
+			// The root of the git repository is a path with symlinks resolved.
+			p, err := filepath.EvalSymlinks(filename)
+			if err != nil {
+				return false
+			}
+			filename = p
+		}
+	}
+	if _, ok := c.all[filename]; ok {
+		return true
+	}
+	lines, ok := c.lines[filename]
+	if !ok {
+		return false
+	}
+	if end < start {
+		end = start
+	}
+	for l := start; l <= end; l++ {
+		if _, ok := lines[l]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func gitRoot() (string, error) {
+	out, err := git("", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...) //nolint:gosec
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package gitdiff
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const patch = `diff --git a/a.go b/a.go
+index 3b18e51..a2b1c0e 100644
+--- a/a.go
++++ b/a.go
+@@ -3,2 +3,3 @@ package a
+ const a = 1
+-const B_b = 2
++const C_c = 3
++const D_d = 4
+@@ -10 +11,0 @@ func f() {
+-	return
+diff --git a/b/b.go b/b/b.go
+new file mode 100644
+index 0000000..e69de29
+--- /dev/null
++++ b/b/b.go
+@@ -0,0 +1,2 @@
++package b
++const E_e = 5
+diff --git a/c.go b/c.go
+deleted file mode 100644
+index e69de29..0000000
+--- a/c.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package a
+`
+
+func TestParse(t *testing.T) {
+	root := t.TempDir()
+	c, err := Parse(strings.NewReader(patch), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		file  string
+		start int
+		end   int
+		want  bool
+	}{
+		{"a.go", 3, 3, false},
+		{"a.go", 4, 4, true},
+		{"a.go", 5, 5, true},
+		{"a.go", 6, 6, false},
+		{"a.go", 2, 4, true},
+		{"a.go", 11, 11, false},
+		{"b/b.go", 1, 1, true},
+		{"b/b.go", 2, 0, true},
+		{"c.go", 1, 1, false},
+		{"d.go", 1, 1, false},
+	}
+	for _, tt := range tests {
+		got := c.Contains(filepath.Join(root, tt.file), tt.start, tt.end)
+		if got != tt.want {
+			t.Errorf("%s:%d-%d: got %v want %v", tt.file, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse(strings.NewReader("@@ -1 +1 @@\n+a\n"), t.TempDir()); err == nil {
+		t.Error("want error")
+	}
+}
@@ -4,7 +4,6 @@ go 1.25.8
 
 require (
 	github.com/bmatcuk/doublestar/v4 v4.10.0
-	github.com/fatih/camelcase v1.0.0
 	github.com/goccy/go-yaml v1.19.2
 	github.com/gostaticanalysis/comment v1.5.0
 	github.com/gostaticanalysis/testutil v0.6.1
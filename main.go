@@ -11,7 +11,7 @@ import (
 )
 
 func main() {
-	if len(os.Args) == 1 || (len(os.Args) > 1 && slices.Contains([]string{"run", "init", "completion", "-v", "help", "-h"}, os.Args[1])) {
+	if len(os.Args) == 1 || (len(os.Args) > 1 && slices.Contains([]string{"run", "fix", "suppressions", "init", "config", "completion", "-v", "help", "-h"}, os.Args[1])) {
 		cmd.Execute()
 		return
 	}
@@ -0,0 +1,103 @@
+// Package meta provides the metadata of analyzers (the style source and the reference URL).
+package meta
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Style sources of analyzers. The analyzers are organized on disk by the style sources (analyzer/<source>/<name>).
+const (
+	// SourceGuide is the Guide of Go Style in Google Style Guides.
+	SourceGuide = "guide"
+	// SourceDecisions is the Decisions of Go Style in Google Style Guides.
+	SourceDecisions = "decisions"
+	// SourceEffective is Effective Go.
+	SourceEffective = "effective"
+	// SourceCodeReviewComments is Go Code Review Comments in Go wiki.
+	SourceCodeReviewComments = "code_review_comments"
+	// SourceGostyle is the analyzers of gostyle itself (e.g. nostyle).
+	SourceGostyle = "gostyle"
+)
+
+// Sources is the list of style sources.
+var Sources = []string{
+	SourceGuide,
+	SourceDecisions,
+	SourceEffective,
+	SourceCodeReviewComments,
+	SourceGostyle,
+}
+
+// titles is the titles of style sources.
+var titles = map[string]string{
+	SourceGuide:              "Go Style Guide (Google Style Guides)",
+	SourceDecisions:          "Go Style Decisions (Google Style Guides)",
+	SourceEffective:          "Effective Go",
+	SourceCodeReviewComments: "Go Code Review Comments (Go wiki)",
+	SourceGostyle:            "Analyzers of gostyle",
+}
+
+// Meta is the metadata of an analyzer.
+type Meta struct {
+	// Name is the name of the analyzer.
+	Name string
+	// Source is the style source of the analyzer.
+	Source string
+	// URL is the reference URL of the style.
+	URL string
+	// Kinds is the kinds of the messages of the analyzer (the keys of kind-severity).
+	Kinds []string
+}
+
+var (
+	registered = map[string]Meta{}
+	mu         sync.RWMutex
+)
+
+// Register registers the metadata of the analyzer.
+func Register(m Meta) {
+	mu.Lock()
+	defer mu.Unlock()
+	registered[m.Name] = m
+}
+
+// Of returns the metadata of the analyzer.
+func Of(name string) (Meta, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	m, ok := registered[name]
+	return m, ok
+}
+
+// SourceOf returns the style source of the analyzer (empty if not registered).
+func SourceOf(name string) string {
+	m, _ := Of(name)
+	return m.Source
+}
+
+// All returns the metadata of all registered analyzers ordered by the style source and the name.
+func All() []Meta {
+	mu.RLock()
+	defer mu.RUnlock()
+	var all []Meta
+	for _, m := range registered {
+		all = append(all, m)
+	}
+	slices.SortFunc(all, func(a, b Meta) int {
+		if c := slices.Index(Sources, a.Source) - slices.Index(Sources, b.Source); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return all
+}
+
+// Title returns the title of the style source.
+func Title(source string) string {
+	if t, ok := titles[source]; ok {
+		return t
+	}
+	return source
+}
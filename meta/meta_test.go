@@ -0,0 +1,32 @@
+package meta
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	for _, m := range []Meta{
+		{Name: "useq", Source: SourceDecisions},
+		{Name: "nostyle", Source: SourceGostyle},
+		{Name: "contexts", Source: SourceCodeReviewComments},
+		{Name: "mixedcaps", Source: SourceGuide},
+		{Name: "getters", Source: SourceDecisions},
+	} {
+		Register(m)
+	}
+	var got []string
+	for _, m := range All() {
+		got = append(got, m.Name)
+	}
+	want := []string{"mixedcaps", "getters", "useq", "contexts", "nostyle"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	if got := SourceOf("useq"); got != SourceDecisions {
+		t.Errorf("got %v want %v", got, SourceDecisions)
+	}
+	if got := SourceOf("unknown"); got != "" {
+		t.Errorf("got %v want empty", got)
+	}
+}
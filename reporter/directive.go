@@ -0,0 +1,252 @@
+package reporter
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Scopes of directives.
+const (
+	// ScopeLine is the scope of the line of the directive (or the declaration if the directive is in its doc comment).
+	ScopeLine = "line"
+	// ScopeFile is the scope of the file (e.g. `//nostyle:file:mixedcaps`).
+	ScopeFile = "file"
+	// ScopeBegin is the beginning of the range that ends with ScopeEnd (e.g. `//nostyle:begin mixedcaps`).
+	ScopeBegin = "begin"
+	// ScopeEnd is the end of the range (e.g. `//nostyle:end`).
+	ScopeEnd = "end"
+)
+
+// Directive is an ignore directive in a comment (e.g. `//nostyle:mixedcaps`).
+type Directive struct {
+	Comment *ast.Comment
+	Scope   string
+	// Analyzers is the list of analyzer names in the directive (e.g. `//nostyle:mixedcaps,varnames`).
+	Analyzers []string
+	// Reason is the reason for the directive (e.g. `//nostyle:mixedcaps // reason` or `//nostyle:mixedcaps -- reason`).
+	Reason string
+	// Pos and End are the positions of the list of analyzer names.
+	Pos token.Pos
+	End token.Pos
+}
+
+// ParseDirective parses the ignore directive (e.g. `//nostyle:mixedcaps`) in the comment.
+// The directive is at the beginning of the comment or follows another comment (e.g. a nolint directive).
+func ParseDirective(c *ast.Comment, annotation string) (*Directive, bool) {
+	t := c.Text
+	if !strings.HasPrefix(t, "//") {
+		return nil, false
+	}
+	i := directiveIndex(t, annotation+sep)
+	if i < 0 {
+		return nil, false
+	}
+	i += len(annotation + sep)
+	scope := ScopeLine
+	switch {
+	case strings.HasPrefix(t[i:], ScopeFile+sep):
+		scope = ScopeFile
+		i += len(ScopeFile + sep)
+	case isWord(t[i:], ScopeBegin):
+		scope = ScopeBegin
+		i += len(ScopeBegin)
+		for i < len(t) && unicode.IsSpace(rune(t[i])) {
+			i++
+		}
+	case isWord(t[i:], ScopeEnd):
+		return &Directive{
+			Comment: c,
+			Scope:   ScopeEnd,
+			Pos:     c.Pos() + token.Pos(i),
+			End:     c.Pos() + token.Pos(i+len(ScopeEnd)),
+		}, true
+	}
+	names := t[i:]
+	if j := strings.IndexFunc(names, unicode.IsSpace); j >= 0 {
+		names = names[:j]
+	}
+	d := &Directive{
+		Comment: c,
+		Scope:   scope,
+		Reason:  reason(t[i+len(names):]),
+		Pos:     c.Pos() + token.Pos(i),
+		End:     c.Pos() + token.Pos(i+len(names)),
+	}
+	for _, n := range strings.Split(names, ",") {
+		if n == "" {
+			continue
+		}
+		d.Analyzers = append(d.Analyzers, n)
+	}
+	return d, true
+}
+
+// HasReason reports whether the directive has the reason.
+func (d *Directive) HasReason() bool {
+	return d.Reason != ""
+}
+
+// Match reports whether the directive names the analyzer (or all analyzers).
+func (d *Directive) Match(name string) bool {
+	return slices.Contains(d.Analyzers, IgnoreAll) || slices.Contains(d.Analyzers, name)
+}
+
+// reason returns the reason that follows `//` or `--` in s.
+func reason(s string) string {
+	s = strings.TrimSpace(s)
+	for _, p := range []string{"//", "--"} {
+		if strings.HasPrefix(s, p) {
+			return strings.TrimSpace(strings.TrimPrefix(s, p))
+		}
+	}
+	return ""
+}
+
+// isWord reports whether s begins with the word w.
+func isWord(s, w string) bool {
+	if !strings.HasPrefix(s, w) {
+		return false
+	}
+	return len(s) == len(w) || unicode.IsSpace(rune(s[len(w)]))
+}
+
+// directiveIndex returns the index of the directive prefix that follows "//" at the beginning of the text or after a space.
+func directiveIndex(t, prefix string) int {
+	for i := 0; i+2 <= len(t); i++ {
+		if t[i:i+2] != "//" || (i > 0 && t[i-1] != ' ' && t[i-1] != '\t') {
+			continue
+		}
+		j := i + 2
+		for j < len(t) && (t[j] == ' ' || t[j] == '\t') {
+			j++
+		}
+		if strings.HasPrefix(t[j:], prefix) {
+			return j
+		}
+	}
+	return -1
+}
+
+// usages is the record of analyzers that ran and directives that suppressed reports.
+var usages = &usage{
+	ran:        map[*ast.File]map[string]struct{}{},
+	suppressed: map[*ast.Comment]map[string]struct{}{},
+}
+
+type usage struct {
+	ran        map[*ast.File]map[string]struct{}
+	suppressed map[*ast.Comment]map[string]struct{}
+	mu         sync.Mutex
+}
+
+// Ran reports whether the analyzer ran (was not disabled) for the file.
+func Ran(f *ast.File, name string) bool {
+	usages.mu.Lock()
+	defer usages.mu.Unlock()
+	_, ok := usages.ran[f][name]
+	return ok
+}
+
+// Suppressed returns the names of analyzers whose reports were suppressed by the directive comment.
+func Suppressed(c *ast.Comment) []string {
+	usages.mu.Lock()
+	defer usages.mu.Unlock()
+	var names []string
+	for n := range usages.suppressed[c] {
+		names = append(names, n)
+	}
+	return names
+}
+
+func (u *usage) run(files []*ast.File, name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for _, f := range files {
+		if _, ok := u.ran[f]; !ok {
+			u.ran[f] = map[string]struct{}{}
+		}
+		u.ran[f][name] = struct{}{}
+	}
+}
+
+func (u *usage) suppress(c *ast.Comment, name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.suppressed[c]; !ok {
+		u.suppressed[c] = map[string]struct{}{}
+	}
+	u.suppressed[c][name] = struct{}{}
+}
+
+// scope is the range of positions where the directive suppresses reports.
+type scope struct {
+	pos token.Pos
+	end token.Pos
+	d   *Directive
+}
+
+// scopes returns the ranges covered by file-scoped directives, directives in doc comments of declarations,
+// and `begin`/`end` directives in the file.
+func scopes(f *ast.File, annotation string) []scope {
+	var s []scope
+	var begins []*Directive
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			d, ok := ParseDirective(c, annotation)
+			if !ok {
+				continue
+			}
+			switch d.Scope {
+			case ScopeFile:
+				s = append(s, scope{pos: f.FileStart, end: f.FileEnd, d: d})
+			case ScopeBegin:
+				begins = append(begins, d)
+			case ScopeEnd:
+				if len(begins) == 0 {
+					continue
+				}
+				b := begins[len(begins)-1]
+				begins = begins[:len(begins)-1]
+				s = append(s, scope{pos: b.Comment.Pos(), end: d.Comment.End(), d: b})
+			}
+		}
+	}
+	// unclosed ranges end at the end of the file.
+	for _, b := range begins {
+		s = append(s, scope{pos: b.Comment.Pos(), end: f.FileEnd, d: b})
+	}
+
+	doc := func(cg *ast.CommentGroup, pos, end token.Pos) {
+		if cg == nil {
+			return
+		}
+		for _, c := range cg.List {
+			d, ok := ParseDirective(c, annotation)
+			if !ok || d.Scope != ScopeLine {
+				continue
+			}
+			s = append(s, scope{pos: pos, end: end, d: d})
+		}
+	}
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			doc(decl.Doc, decl.Pos(), decl.End())
+		case *ast.GenDecl:
+			doc(decl.Doc, decl.Pos(), decl.End())
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					doc(spec.Doc, spec.Pos(), spec.End())
+				case *ast.ValueSpec:
+					doc(spec.Doc, spec.Pos(), spec.End())
+				}
+			}
+		}
+	}
+	return s
+}
@@ -2,14 +2,20 @@ package reporter
 
 import (
 	"fmt"
+	"go/ast"
 	"go/token"
+	"go/types"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/gostaticanalysis/comment"
 	"github.com/gostaticanalysis/comment/passes/commentmap"
+	"github.com/k1LoW/gostyle/baseline"
+	"github.com/k1LoW/gostyle/detector"
+	"github.com/k1LoW/gostyle/gitdiff"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -22,8 +28,36 @@ const (
 	IgnoreAll                = "all"
 )
 
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Severities is the list of severities in ascending order of importance.
+var Severities = []string{
+	SeverityInfo,
+	SeverityWarning,
+	SeverityError,
+}
+
 var codegenRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
 
+var (
+	bl      *baseline.Baseline
+	changes *gitdiff.Changes
+)
+
+// SetBaseline sets the baseline. Reports are recorded to the baseline, and reports in the baseline are not reported.
+func SetBaseline(b *baseline.Baseline) {
+	bl = b
+}
+
+// SetChanges sets the changed lines. Reports outside the changed lines are not reported.
+func SetChanges(c *gitdiff.Changes) {
+	changes = c
+}
+
 // Reporter is a wrapper of analysis.Pass.Reportf.
 type Reporter struct {
 	name              string
@@ -34,19 +68,31 @@ type Reporter struct {
 	ignoreAnotation   string
 	disableLintIgnore bool
 	disableNoLint     bool
+	disableNoStyle    bool
+	requireReason     bool
 	includeGenerated  bool
+	excludeTest       bool
 	configDir         string
 	excludeFiles      []string
+	excludePackages   []string
+	severity          string
+	kindSeverity      map[string]string
+	scopes            map[*ast.File][]scope
 }
 
 type report struct {
-	pos token.Pos
-	end token.Pos
-	msg string
+	pos   token.Pos
+	end   token.Pos
+	msg   string
+	kind  string
+	fixes []analysis.SuggestedFix
 }
 
 type Option func(*Reporter)
 
+// ReportOption is an option for each report.
+type ReportOption func(*report)
+
 // IgnoreAnotation sets the annotation to ignore the report.
 func IgnoreAnotation(s string) Option {
 	return func(r *Reporter) {
@@ -68,6 +114,20 @@ func DisableNoLint() Option {
 	}
 }
 
+// DisableNoStyle disables handling for '//nostyle:*'.
+func DisableNoStyle() Option {
+	return func(r *Reporter) {
+		r.disableNoStyle = true
+	}
+}
+
+// RequireIgnoreReason makes '//nostyle:*' without the reason (e.g. `// reason` or `-- reason`) invalid.
+func RequireIgnoreReason(require bool) Option {
+	return func(r *Reporter) {
+		r.requireReason = require
+	}
+}
+
 // Prefix sets the prefix of the report.
 func Prefix(s string) Option {
 	return func(r *Reporter) {
@@ -82,6 +142,25 @@ func IncludeGenerated() Option {
 	}
 }
 
+// ExcludeTest excludes test files from the report.
+func ExcludeTest() Option {
+	return func(r *Reporter) {
+		r.excludeTest = true
+	}
+}
+
+// FileOptions returns the options to include generated files and to exclude test files.
+func FileOptions(includeGenerated, excludeTest bool) []Option {
+	var opts []Option
+	if includeGenerated {
+		opts = append(opts, IncludeGenerated())
+	}
+	if excludeTest {
+		opts = append(opts, ExcludeTest())
+	}
+	return opts
+}
+
 // ExcludeFiles excludes files from the report.
 func ExcludeFiles(configDir string, files []string) Option {
 	return func(r *Reporter) {
@@ -90,6 +169,38 @@ func ExcludeFiles(configDir string, files []string) Option {
 	}
 }
 
+// ExcludePackages excludes packages whose import paths match the patterns from the report.
+// A pattern matches the import path or its trailing path elements (e.g. */internal/testutil matches example.com/a/internal/testutil).
+func ExcludePackages(patterns []string) Option {
+	return func(r *Reporter) {
+		r.excludePackages = append(r.excludePackages, patterns...)
+	}
+}
+
+// Severity sets the severity of the reports and the severity per message kind.
+func Severity(severity string, kindSeverity map[string]string) Option {
+	return func(r *Reporter) {
+		if severity != "" {
+			r.severity = severity
+		}
+		r.kindSeverity = kindSeverity
+	}
+}
+
+// Kind sets the message kind of the report.
+func Kind(kind string) ReportOption {
+	return func(rr *report) {
+		rr.kind = kind
+	}
+}
+
+// Fixes sets the suggested fixes of the report.
+func Fixes(fixes ...analysis.SuggestedFix) ReportOption {
+	return func(rr *report) {
+		rr.fixes = fixes
+	}
+}
+
 // New returns a new Reporter.
 func New(name string, pass *analysis.Pass, opts ...Option) (*Reporter, error) {
 	cm, ok := pass.ResultOf[commentmap.Analyzer].(comment.Maps)
@@ -102,40 +213,172 @@ func New(name string, pass *analysis.Pass, opts ...Option) (*Reporter, error) {
 		cm:              cm,
 		prefix:          fmt.Sprintf("[%s.%s] ", defaultPrefixKey, name),
 		ignoreAnotation: NoStyleCommentAnnotation,
+		severity:        SeverityError,
 	}
 	for _, opt := range opts {
 		opt(r)
 	}
+	if !slices.Contains(Severities, r.severity) {
+		return nil, fmt.Errorf("invalid severity of %s: %s", name, r.severity)
+	}
+	for k, s := range r.kindSeverity {
+		if !slices.Contains(Severities, s) {
+			return nil, fmt.Errorf("invalid severity of %s (%s): %s", name, k, s)
+		}
+	}
 	var excludeFiles []string
 	if len(r.excludeFiles) > 0 {
 		for _, f := range r.excludeFiles {
-			p := filepath.Join(r.configDir, f)
+			p := f
+			if !filepath.IsAbs(p) {
+				p = filepath.Join(r.configDir, p)
+			}
 			excludeFiles = append(excludeFiles, p)
 		}
 	}
 	r.excludeFiles = excludeFiles
+	if r.excludedPackage() {
+		// The analyzer does not run for the excluded package, so its directives are not reported as unused.
+		return r, nil
+	}
+	var files []*ast.File
+	for _, f := range pass.Files {
+		// The analyzer does not run for the excluded test files, so their directives are not reported as unused.
+		if r.excludeTest && isTest(pass.Fset.File(f.Pos()).Name()) {
+			continue
+		}
+		files = append(files, f)
+	}
+	usages.run(files, name)
 
 	return r, nil
 }
 
 // Append appends token.Pos and message to the report.
-func (r *Reporter) Append(pos token.Pos, msg string) {
-	r.reports = append(r.reports, &report{pos: pos, msg: msg})
+func (r *Reporter) Append(pos token.Pos, msg string, opts ...ReportOption) {
+	rr := &report{pos: pos, msg: msg}
+	for _, opt := range opts {
+		opt(rr)
+	}
+	r.reports = append(r.reports, rr)
 }
 
 // AppendOr appends posititons (start, end) and message to the report.
-func (r *Reporter) AppendOr(pos token.Pos, end token.Pos, msg string) {
-	r.reports = append(r.reports, &report{pos: pos, end: end, msg: msg})
+func (r *Reporter) AppendOr(pos token.Pos, end token.Pos, msg string, opts ...ReportOption) {
+	rr := &report{pos: pos, end: end, msg: msg}
+	for _, opt := range opts {
+		opt(rr)
+	}
+	r.reports = append(r.reports, rr)
+}
+
+// AppendWithFixes appends token.Pos, message and suggested fixes to the report.
+func (r *Reporter) AppendWithFixes(pos token.Pos, msg string, fixes ...analysis.SuggestedFix) {
+	r.reports = append(r.reports, &report{pos: pos, msg: msg, fixes: fixes})
 }
 
 // Report reports all reports.
 func (r *Reporter) Report() {
+	if r.excludedPackage() {
+		return
+	}
 	for _, rr := range r.reports {
 		if r.ignoreReport(rr.pos) || r.ignoreReport(rr.end) {
 			continue
 		}
-		r.pass.Reportf(rr.pos, "%s%s", r.prefix, rr.msg)
+		if bl != nil {
+			posn := r.pass.Fset.Position(rr.pos)
+			decl := r.enclosingDecl(rr.pos)
+			bl.Record(r.name, posn, decl, rr.msg)
+			if bl.Match(r.name, posn, decl, rr.msg) {
+				continue
+			}
+		}
+		if changes != nil && !r.changed(rr) {
+			continue
+		}
+		r.pass.Report(analysis.Diagnostic{
+			Pos:            rr.pos,
+			Category:       r.severityOf(rr.kind),
+			Message:        r.prefix + rr.msg,
+			SuggestedFixes: rr.fixes,
+		})
+	}
+}
+
+func isTest(filename string) bool {
+	return strings.HasSuffix(filename, "_test.go")
+}
+
+// excludedPackage reports whether the package is excluded.
+func (r *Reporter) excludedPackage() bool {
+	if len(r.excludePackages) == 0 || r.pass.Pkg == nil {
+		return false
+	}
+	for _, p := range r.excludePackages {
+		if detector.MatchPackage(p, r.pass.Pkg.Path()) {
+			return true
+		}
+	}
+	return false
+}
+
+// severityOf returns the severity of the message kind.
+// The severity is reported as the category of the diagnostic.
+func (r *Reporter) severityOf(kind string) string {
+	if s, ok := r.kindSeverity[kind]; ok && kind != "" {
+		return s
+	}
+	return r.severity
+}
+
+// changed reports whether the report is on the changed lines.
+// The positions are not adjusted by line directives, since the lines of the diff are the lines of the file.
+func (r *Reporter) changed(rr *report) bool {
+	start := r.pass.Fset.PositionFor(rr.pos, false)
+	end := start
+	if rr.end.IsValid() {
+		end = r.pass.Fset.PositionFor(rr.end, false)
+	}
+	return changes.Contains(start.Filename, start.Line, end.Line)
+}
+
+// enclosingDecl returns the name of the top-level declaration enclosing pos (e.g. "func (*T).Foo", "type T").
+func (r *Reporter) enclosingDecl(pos token.Pos) string {
+	for _, f := range r.pass.Files {
+		if pos < f.FileStart || pos > f.FileEnd {
+			continue
+		}
+		for _, d := range f.Decls {
+			if pos < d.Pos() || pos > d.End() {
+				continue
+			}
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					return fmt.Sprintf("func %s", d.Name.Name)
+				}
+				return fmt.Sprintf("func (%s).%s", types.ExprString(d.Recv.List[0].Type), d.Name.Name)
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if pos < spec.Pos() || pos > spec.End() {
+						continue
+					}
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						return fmt.Sprintf("%s %s", d.Tok, s.Name.Name)
+					case *ast.ValueSpec:
+						if len(s.Names) > 0 {
+							return fmt.Sprintf("%s %s", d.Tok, s.Names[0].Name)
+						}
+					}
+				}
+				return d.Tok.String()
+			}
+		}
+		return ""
 	}
+	return ""
 }
 
 func (r *Reporter) ignoreReport(pos token.Pos) bool {
@@ -144,6 +387,9 @@ func (r *Reporter) ignoreReport(pos token.Pos) bool {
 	}
 
 	f1 := r.pass.Fset.File(pos)
+	if r.excludeTest && isTest(f1.Name()) {
+		return true
+	}
 	for _, e := range r.excludeFiles {
 		match, err := doublestar.PathMatch(e, f1.Name())
 		if err != nil {
@@ -196,17 +442,53 @@ func (r *Reporter) ignoreReport(pos token.Pos) bool {
 							return true
 						}
 					}
-					// 'nostyle:all'
-					if strings.Contains(t, fmt.Sprintf("%s%s%s", r.ignoreAnotation, sep, IgnoreAll)) {
-						return true
-					}
-					// 'nostyle:' and r.name
-					if strings.Contains(t, r.ignoreAnotation+sep) && strings.Contains(t, r.name) {
-						return true
+					// 'nostyle:all' or 'nostyle:' and r.name
+					if d, ok := ParseDirective(c, r.ignoreAnotation); ok && !r.disableNoStyle {
+						if d.Scope == ScopeLine && d.Match(r.name) && r.valid(d) {
+							usages.suppress(c, r.name)
+							return true
+						}
 					}
 				}
 			}
 		}
 	}
+	return r.ignoreInScope(pos)
+}
+
+// valid reports whether the directive is valid.
+func (r *Reporter) valid(d *Directive) bool {
+	return !r.requireReason || d.HasReason()
+}
+
+// ignoreInScope reports whether pos is in the scope of a file-scoped directive, a directive in the doc comment of the declaration,
+// or a range of `begin`/`end` directives.
+func (r *Reporter) ignoreInScope(pos token.Pos) bool {
+	if r.disableNoStyle {
+		return false
+	}
+	for _, f := range r.pass.Files {
+		if pos < f.FileStart || pos > f.FileEnd {
+			continue
+		}
+		if r.scopes == nil {
+			r.scopes = map[*ast.File][]scope{}
+		}
+		s, ok := r.scopes[f]
+		if !ok {
+			s = scopes(f, r.ignoreAnotation)
+			r.scopes[f] = s
+		}
+		// All directives covering pos are marked as used, since they may overlap.
+		ignore := false
+		for _, ss := range s {
+			if pos < ss.pos || pos > ss.end || !ss.d.Match(r.name) || !r.valid(ss.d) {
+				continue
+			}
+			usages.suppress(ss.d.Comment, r.name)
+			ignore = true
+		}
+		return ignore
+	}
 	return false
 }
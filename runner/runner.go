@@ -0,0 +1,121 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/gostyle/reporter"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Diagnostic is an analysis.Diagnostic with the analyzer that reported it.
+type Diagnostic struct {
+	analysis.Diagnostic
+	Analyzer *analysis.Analyzer
+	Posn     token.Position
+	Severity string
+}
+
+// Result is the result of running analyzers.
+type Result struct {
+	Fset        *token.FileSet
+	Diagnostics []*Diagnostic
+}
+
+// Analyze loads the packages specified by patterns (including tests) and applies the analyzers to them.
+// The diagnostics are deduplicated and sorted by position.
+func Analyze(analyzers []*analysis.Analyzer, patterns ...string) (*Result, error) {
+	fset := token.NewFileSet()
+	conf := &packages.Config{
+		Mode:  packages.LoadAllSyntax | packages.NeedModule,
+		Tests: true,
+		Fset:  fset,
+	}
+	pkgs, err := packages.Load(conf, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("%s matched no packages", strings.Join(patterns, " "))
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("failed to load packages: %d error(s)", n)
+	}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	seen := map[string]*Diagnostic{}
+	res := &Result{Fset: fset}
+	for act := range graph.All() {
+		if act.Err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", act, act.Err))
+			continue
+		}
+		if !act.IsRoot {
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			posn := fset.Position(d.Pos)
+			// The same file may be analyzed more than once (e.g. package and package with tests).
+			// The suggested fixes are merged, since only the package with tests sees the uses in the test files.
+			k := fmt.Sprintf("%s %s %s", posn, act.Analyzer.Name, d.Message)
+			if prev, ok := seen[k]; ok {
+				prev.SuggestedFixes = mergeFixes(prev.SuggestedFixes, d.SuggestedFixes)
+				continue
+			}
+			// reporter reports the severity as the category of the diagnostic.
+			severity := d.Category
+			if !slices.Contains(reporter.Severities, severity) {
+				severity = reporter.SeverityError
+			}
+			rd := &Diagnostic{
+				Diagnostic: d,
+				Analyzer:   act.Analyzer,
+				Posn:       posn,
+				Severity:   severity,
+			}
+			seen[k] = rd
+			res.Diagnostics = append(res.Diagnostics, rd)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	sort.SliceStable(res.Diagnostics, func(i, j int) bool {
+		pi, pj := res.Diagnostics[i].Posn, res.Diagnostics[j].Posn
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+	return res, nil
+}
+
+// mergeFixes merges the text edits of the suggested fixes with the same message.
+// The identical edits are coalesced by fixer.Resolve.
+func mergeFixes(fixes, others []analysis.SuggestedFix) []analysis.SuggestedFix {
+	merged := slices.Clone(fixes)
+	for _, o := range others {
+		i := slices.IndexFunc(merged, func(f analysis.SuggestedFix) bool {
+			return f.Message == o.Message
+		})
+		if i < 0 {
+			merged = append(merged, o)
+			continue
+		}
+		merged[i].TextEdits = append(slices.Clone(merged[i].TextEdits), o.TextEdits...)
+	}
+	return merged
+}